@@ -65,6 +65,7 @@ defaulter-gen \
   --go-header-file ${HEADER_FILE}
 openapi-gen \
   --input-dirs k8c.io/bulward/pkg/apis/apiserver/v1alpha1 \
+  --input-dirs k8c.io/bulward/pkg/apis/core/v1alpha1 \
   --input-dirs k8c.io/bulward/pkg/apis/storage/v1alpha1 \
   --input-dirs k8s.io/apimachinery/pkg/apis/meta/v1,k8s.io/apimachinery/pkg/api/resource,k8s.io/apimachinery/pkg/version,k8s.io/apimachinery/pkg/runtime,k8s.io/apimachinery/pkg/util/intstr,k8s.io/api/core/v1,k8s.io/api/apps/v1,k8s.io/api/rbac/v1 \
   --go-header-file ${HEADER_FILE} \
@@ -0,0 +1,135 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+
+	"k8c.io/utils/pkg/owner"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/filters"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+// checkProjectCreation checks if the calling user may create the Project:
+// The Project namespace must belong to a ready Organization, and the user must be authorized to create Projects in it.
+// It returns NotFound if the user is no member of the Organization, and Forbidden if the user is a member without access.
+func checkProjectCreation(ctx context.Context, c client.Client, scheme *runtime.Scheme, project *Project) error {
+	org, ready, err := getOrganizationByNamespace(ctx, c, scheme, project.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := checkMembership(ctx, org); err != nil {
+		return err
+	}
+	if !ready {
+		return apierrors.NewForbidden(
+			project.GetQualifiedResource(),
+			project.Name,
+			fmt.Errorf("organization %s is not ready", org.Name),
+		)
+	}
+	allowed, err := isAuthorized(ctx, c, &authorizationv1.ResourceAttributes{
+		Namespace: project.Namespace,
+		Verb:      "create",
+		Group:     SchemeGroupVersion.Group,
+		Resource:  externalProjectResource,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierrors.NewForbidden(
+			project.GetQualifiedResource(),
+			project.Name,
+			fmt.Errorf("creating projects in organization %s is not allowed", org.Name),
+		)
+	}
+	return nil
+}
+
+// getOrganizationByNamespace returns the Organization owning the given namespace and whether it is ready,
+// or NotFound if there is no such Organization. The Organization is looked up via the owner labels of the namespace.
+func getOrganizationByNamespace(ctx context.Context, c client.Client, scheme *runtime.Scheme, namespace string) (*Organization, bool, error) {
+	notFound := apierrors.NewNotFound(Resource(externalOrganizationResource), namespace)
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, notFound
+		}
+		return nil, false, err
+	}
+	if ns.Labels[owner.OwnerTypeLabel] != storagev1alpha1.SchemeGroupVersion.WithKind("Organization").GroupKind().String() ||
+		ns.Labels[owner.OwnerNameLabel] == "" {
+		return nil, false, notFound
+	}
+
+	storageOrg := &storagev1alpha1.Organization{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ns.Labels[owner.OwnerNameLabel]}, storageOrg); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, notFound
+		}
+		return nil, false, err
+	}
+	if storageOrg.Status.Namespace == nil || storageOrg.Status.Namespace.Name != namespace {
+		// The labels of the namespace can't be trusted on their own.
+		return nil, false, notFound
+	}
+	org := &Organization{}
+	if err := scheme.Convert(storageOrg, org, nil); err != nil {
+		return nil, false, err
+	}
+	return org, storageOrg.IsReady(), nil
+}
+
+// isAuthorized checks via SubjectAccessReview whether the calling user is allowed to access the given resource.
+func isAuthorized(ctx context.Context, c client.Client, resourceAttributes *authorizationv1.ResourceAttributes) (bool, error) {
+	attrs, err := filters.GetAuthorizerAttributes(ctx)
+	if err != nil {
+		return false, err
+	}
+	user := attrs.GetUser()
+	if user == nil {
+		klog.Warning("unknown user, you may running API extension server with --delegated-auth=false")
+		return true, nil
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.GetExtra() {
+		extra[k] = v
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: resourceAttributes,
+			User:               user.GetName(),
+			Groups:             user.GetGroups(),
+			UID:                user.GetUID(),
+			Extra:              extra,
+		},
+	}
+	if err := c.Create(ctx, sar); err != nil {
+		return false, fmt.Errorf("creating SubjectAccessReview: %w", err)
+	}
+	return sar.Status.Allowed, nil
+}
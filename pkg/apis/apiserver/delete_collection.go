@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deleteCollectionItemOptions returns the DeleteOptions for deleting a single item of a collection.
+// The UID precondition makes sure that only the item that was checked is deleted.
+func deleteCollectionItemOptions(options *metav1.DeleteOptions, uid types.UID) metav1.DeleteOptions {
+	opts := options.DeepCopy()
+	opts.Preconditions = metav1.NewUIDPreconditions(string(uid))
+	return *opts
+}
+
+// deleteCollectionFailure describes why an item of a collection could not be deleted.
+func deleteCollectionFailure(name string, err error) metav1.StatusCause {
+	return metav1.StatusCause{
+		Type:    metav1.CauseType(apierrors.ReasonForError(err)),
+		Message: err.Error(),
+		Field:   name,
+	}
+}
+
+// DeletedCauseType marks the StatusCauses of a failed DeleteCollection request, that name the items that have been deleted.
+// The Field of the cause holds the name of the deleted item, like for the causes of the items that could not be deleted.
+const DeletedCauseType metav1.CauseType = "Deleted"
+
+// newDeleteCollectionError reports the items that could not be deleted, next to the items that have been deleted.
+// Both are listed in the causes of the error, so clients don't have to parse the message.
+// If all failures are caused by missing permissions, the error is Forbidden, otherwise it is an InternalError.
+func newDeleteCollectionError(qualifiedResource schema.GroupResource, deleted []string, failures []metav1.StatusCause) *apierrors.StatusError {
+	reason, code := metav1.StatusReasonForbidden, int32(http.StatusForbidden)
+	failed := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		failed = append(failed, failure.Field)
+		if failure.Type != metav1.CauseType(metav1.StatusReasonForbidden) {
+			reason, code = metav1.StatusReasonInternalError, http.StatusInternalServerError
+		}
+	}
+	causes := append([]metav1.StatusCause{}, failures...)
+	for _, name := range deleted {
+		causes = append(causes, metav1.StatusCause{
+			Type:    DeletedCauseType,
+			Message: "deleted",
+			Field:   name,
+		})
+	}
+	return &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   code,
+		Reason: reason,
+		Details: &metav1.StatusDetails{
+			Group:  qualifiedResource.Group,
+			Kind:   qualifiedResource.Resource,
+			Causes: causes,
+		},
+		Message: fmt.Sprintf("failed to delete %s [%s], deleted [%s]",
+			qualifiedResource.String(), strings.Join(failed, ", "), strings.Join(deleted, ", ")),
+	}}
+}
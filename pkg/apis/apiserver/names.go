@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"k8c.io/bulward/pkg/validation"
+)
+
+// checkReservedName checks that the given name is not reserved, and returns an Invalid error on metadata.name otherwise.
+func checkReservedName(reservedNames *validation.ReservedNames, kind, name string) error {
+	if reservedNames == nil {
+		return nil
+	}
+	if errs := reservedNames.ValidateName(name, field.NewPath("metadata", "name")); len(errs) > 0 {
+		return apierrors.NewInvalid(Kind(kind), name, errs)
+	}
+	return nil
+}
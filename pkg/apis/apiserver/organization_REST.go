@@ -20,22 +20,29 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/apiserver/pkg/endpoints/filters"
 	"k8s.io/apiserver/pkg/registry/generic"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/validation"
 )
 
 const (
@@ -49,6 +56,8 @@ type OrganizationREST struct {
 	dynamicRI dynamic.ResourceInterface
 	mapper    meta.RESTMapper
 	scheme    *runtime.Scheme
+
+	reservedNames *validation.ReservedNames
 }
 
 var OrganizationRESTSingleton = &OrganizationREST{}
@@ -92,6 +101,14 @@ func (o *OrganizationREST) InjectScheme(scheme *runtime.Scheme) error {
 	return nil
 }
 
+func (o *OrganizationREST) InjectReservedNames(reservedNames validation.ReservedNames) error {
+	if o.reservedNames != nil {
+		return fmt.Errorf("reservedNames already injected")
+	}
+	o.reservedNames = &reservedNames
+	return nil
+}
+
 var _ rest.Storage = (*OrganizationREST)(nil)
 var _ rest.Scoper = (*OrganizationREST)(nil)
 var _ rest.Getter = (*OrganizationREST)(nil)
@@ -115,26 +132,116 @@ func (o *OrganizationREST) NewList() runtime.Object {
 }
 
 func (o *OrganizationREST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
-	uOrg, err := o.dynamicRI.Get(ctx, name, *options)
+	_, org, err := o.getStorageOrganization(ctx, name)
 	if err != nil {
 		return nil, err
 	}
+	return org, nil
+}
 
-	org, err := ConvertFromUnstructuredStorageV1Alpha1Organization(uOrg, o.scheme)
+// getStorageOrganization returns the storage Organization which is visible to the calling user under the given name.
+// Storage Organizations are created with a generated name and carry the user facing name as OrganizationNameLabel,
+// Organizations without this label are looked up by their name.
+func (o *OrganizationREST) getStorageOrganization(ctx context.Context, name string) (*unstructured.Unstructured, *Organization, error) {
+	var (
+		uOrg *unstructured.Unstructured
+		org  *Organization
+	)
+	if len(utilvalidation.IsValidLabelValue(name)) == 0 {
+		uOrgs, err := o.dynamicRI.List(ctx, metav1.ListOptions{
+			LabelSelector: labels.Set{storagev1alpha1.OrganizationNameLabel: name}.String(),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range uOrgs.Items {
+			candidate, err := ConvertFromUnstructuredStorageV1Alpha1Organization(&uOrgs.Items[i], o.scheme)
+			if err != nil {
+				return nil, nil, err
+			}
+			visible, err := isMember(ctx, candidate)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !visible {
+				continue
+			}
+			// The same name may only be visible multiple times if Organizations were created concurrently,
+			// pick the oldest one so the name keeps pointing to the same Organization.
+			if org == nil || candidate.CreationTimestamp.Before(&org.CreationTimestamp) {
+				uOrg, org = &uOrgs.Items[i], candidate
+			}
+		}
+		if org != nil {
+			return uOrg, org, nil
+		}
+	}
+
+	uOrg, err := o.dynamicRI.Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return nil, err
+		if apierrors.IsNotFound(err) {
+			return nil, nil, apierrors.NewNotFound(Resource(externalOrganizationResource), name)
+		}
+		return nil, nil, err
+	}
+	if _, ok := uOrg.GetLabels()[storagev1alpha1.OrganizationNameLabel]; ok {
+		// This Organization is addressed by its alias.
+		return nil, nil, apierrors.NewNotFound(Resource(externalOrganizationResource), name)
+	}
+	org, err = ConvertFromUnstructuredStorageV1Alpha1Organization(uOrg, o.scheme)
+	if err != nil {
+		return nil, nil, err
 	}
 	if err := checkMembership(ctx, org); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return org, nil
+	return uOrg, org, nil
 }
 
-func (o *OrganizationREST) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+// storageListOptions converts the given ListOptions into ListOptions for storage Organizations.
+// As storage Organizations are stored under a generated name, a metadata.name field selector is
+// translated into a label selector for the OrganizationNameLabel.
+func (o *OrganizationREST) storageListOptions(options *internalversion.ListOptions) (*metav1.ListOptions, error) {
 	opts := &metav1.ListOptions{}
 	if err := o.scheme.Convert(options, opts, nil); err != nil {
 		return nil, err
 	}
+	if options == nil || options.FieldSelector == nil {
+		return opts, nil
+	}
+	name, found := options.FieldSelector.RequiresExactMatch("metadata.name")
+	if !found {
+		return opts, nil
+	}
+
+	fieldSelector, err := options.FieldSelector.Transform(func(field, value string) (string, string, error) {
+		if field == "metadata.name" {
+			return "", "", nil
+		}
+		return field, value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts.FieldSelector = fieldSelector.String()
+
+	labelSelector := labels.Everything()
+	if options.LabelSelector != nil {
+		labelSelector = options.LabelSelector
+	}
+	requirement, err := labels.NewRequirement(storagev1alpha1.OrganizationNameLabel, selection.Equals, []string{name})
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid organization name %q in field selector: %v", name, err))
+	}
+	opts.LabelSelector = labelSelector.Add(*requirement).String()
+	return opts, nil
+}
+
+func (o *OrganizationREST) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+	opts, err := o.storageListOptions(options)
+	if err != nil {
+		return nil, err
+	}
 	orgs, err := o.dynamicRI.List(ctx, *opts)
 	if err != nil {
 		return nil, err
@@ -168,9 +275,20 @@ func (o *OrganizationREST) Create(ctx context.Context, obj runtime.Object, creat
 		return nil, err
 	}
 	org := obj.(*Organization)
+	if org.Name == "" && org.GenerateName != "" {
+		org.Name = names.SimpleNameGenerator.GenerateName(org.GenerateName)
+	}
 	if err := createValidation(ctx, obj); err != nil {
 		return nil, err
 	}
+	if err := checkReservedName(o.reservedNames, "Organization", org.Name); err != nil {
+		return nil, err
+	}
+	if errs := utilvalidation.IsValidLabelValue(org.Name); len(errs) > 0 {
+		return nil, apierrors.NewInvalid(Kind("Organization"), org.Name, field.ErrorList{
+			field.Invalid(field.NewPath("metadata", "name"), org.Name, strings.Join(errs, "; ")),
+		})
+	}
 	// Here we're not using checkOwnership since we're returning different error.
 	// User should always include himself/herself in the Owners list, otherwise, we return BadRequest error to
 	// indicate the request is invalid and cannot be processed.
@@ -181,10 +299,21 @@ func (o *OrganizationREST) Create(ctx context.Context, obj runtime.Object, creat
 	if !isOwner {
 		return nil, apierrors.NewBadRequest("cannot create organization you're not the owner of")
 	}
+	// Names only have to be unique across the Organizations the user can see,
+	// so Organizations of other users are not disclosed by AlreadyExists errors.
+	_, _, err = o.getStorageOrganization(ctx, org.Name)
+	switch {
+	case err == nil:
+		return nil, apierrors.NewAlreadyExists(Resource(externalOrganizationResource), org.Name)
+	case !apierrors.IsNotFound(err):
+		return nil, err
+	}
 	u, err := ConvertToUnstructuredStorageV1Alpha1Organization(org, o.scheme)
 	if err != nil {
 		return nil, err
 	}
+	u.SetName("")
+	u.SetGenerateName(org.Name + "-")
 
 	var subresource []string
 	if a.GetSubresource() != "" {
@@ -204,18 +333,10 @@ func (o *OrganizationREST) Update(ctx context.Context, name string, objInfo rest
 		return nil, false, err
 	}
 	preconditions := objInfo.Preconditions()
-	rv := ""
-	if preconditions != nil && preconditions.ResourceVersion != nil {
-		rv = *preconditions.ResourceVersion
-	}
-	objUntyped, err := o.Get(ctx, name, &metav1.GetOptions{
-		TypeMeta:        options.TypeMeta,
-		ResourceVersion: rv,
-	})
+	uOld, oldObj, err := o.getStorageOrganization(ctx, name)
 	if err != nil {
 		return nil, false, err
 	}
-	oldObj := objUntyped.(*Organization)
 	if preconditions != nil && preconditions.UID != nil && oldObj.UID != *preconditions.UID {
 		return nil, false, fmt.Errorf("UID differs, precondition UID: %s, found %s", *preconditions.UID, oldObj.UID)
 	}
@@ -232,11 +353,15 @@ func (o *OrganizationREST) Update(ctx context.Context, name string, objInfo rest
 	if err := updateValidation(ctx, newObj, oldObj); err != nil {
 		return nil, false, err
 	}
+	if err := checkOwnersUpdate(ctx, o.client, "Organization", oldObj, newObj.(*Organization)); err != nil {
+		return nil, false, err
+	}
 
 	u, err := ConvertToUnstructuredStorageV1Alpha1Organization(newObj.(*Organization), o.scheme)
 	if err != nil {
 		return nil, false, err
 	}
+	u.SetName(uOld.GetName())
 
 	var subresource []string
 	if a.GetSubresource() != "" {
@@ -255,43 +380,79 @@ func (o *OrganizationREST) Update(ctx context.Context, name string, objInfo rest
 }
 
 func (o *OrganizationREST) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
-	obj, err := o.Get(ctx, name, &metav1.GetOptions{})
+	u, obj, err := o.getStorageOrganization(ctx, name)
 	if err != nil {
 		return nil, false, err
 	}
 	if err := deleteValidation(ctx, obj); err != nil {
 		return obj, false, err
 	}
-	if err := checkOwnership(ctx, obj.(*Organization)); err != nil {
+	if err := checkOwnership(ctx, obj); err != nil {
 		return nil, false, err
 	}
-	err = o.dynamicRI.Delete(ctx, name, *options)
+	err = o.dynamicRI.Delete(ctx, u.GetName(), *options)
 	return obj, false, err
 }
 
+// DeleteCollection deletes the Organizations, that are visible to and owned by the calling user, one by one.
+// Failures are reported per Organization, the returned list contains the Organizations that have been deleted.
 func (o *OrganizationREST) DeleteCollection(ctx context.Context, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions, listOptions *internalversion.ListOptions) (runtime.Object, error) {
-	orgs, err := o.List(ctx, listOptions)
+	opts, err := o.storageListOptions(listOptions)
+	if err != nil {
+		return nil, err
+	}
+	uOrgs, err := o.dynamicRI.List(ctx, *opts)
 	if err != nil {
 		return nil, err
 	}
-	for _, org := range orgs.(*OrganizationList).Items {
-		if err := checkOwnership(ctx, &org); err != nil {
+
+	deleted := &OrganizationList{}
+	deleted.SetResourceVersion(uOrgs.GetResourceVersion())
+	var (
+		deletedNames []string
+		failures     []metav1.StatusCause
+	)
+	for i := range uOrgs.Items {
+		uOrg := &uOrgs.Items[i]
+		org, err := ConvertFromUnstructuredStorageV1Alpha1Organization(uOrg, o.scheme)
+		if err != nil {
 			return nil, err
 		}
+		visible, err := isMember(ctx, org)
+		if err != nil {
+			return nil, err
+		}
+		if !visible {
+			continue
+		}
+		if err := o.deleteCollectionItem(ctx, uOrg.GetName(), org, deleteValidation, options); err != nil {
+			if !apierrors.IsNotFound(err) {
+				failures = append(failures, deleteCollectionFailure(org.Name, err))
+			}
+			continue
+		}
+		deleted.Items = append(deleted.Items, *org)
+		deletedNames = append(deletedNames, org.Name)
 	}
-	opts := &metav1.ListOptions{}
-	if err := o.scheme.Convert(listOptions, opts, nil); err != nil {
-		return nil, err
+	if len(failures) > 0 {
+		return nil, newDeleteCollectionError(Resource(externalOrganizationResource), deletedNames, failures)
 	}
-	if err := o.dynamicRI.DeleteCollection(ctx, *options, *opts); err != nil {
-		return nil, err
+	return deleted, nil
+}
+
+func (o *OrganizationREST) deleteCollectionItem(ctx context.Context, storageName string, org *Organization, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) error {
+	if err := deleteValidation(ctx, org); err != nil {
+		return err
 	}
-	return orgs, nil
+	if err := checkOwnership(ctx, org); err != nil {
+		return err
+	}
+	return o.dynamicRI.Delete(ctx, storageName, deleteCollectionItemOptions(options, org.UID))
 }
 
 func (o *OrganizationREST) Watch(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
-	opts := &metav1.ListOptions{}
-	if err := o.scheme.Convert(options, opts, nil); err != nil {
+	opts, err := o.storageListOptions(options)
+	if err != nil {
 		return nil, err
 	}
 	wi, err := o.dynamicRI.Watch(ctx, *opts)
@@ -19,12 +19,17 @@ package apiserver
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/endpoints/filters"
 	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"k8c.io/bulward/pkg/validation"
 )
 
 // +k8s:deepcopy-gen=false
@@ -89,6 +94,22 @@ func checkOwnership(ctx context.Context, ownRes OwnableResourceWithMembership) e
 	return nil
 }
 
+// checkOwnersUpdate checks that an update of the owners list doesn't orphan the resource,
+// and returns an Invalid error on the spec.owners field otherwise.
+func checkOwnersUpdate(ctx context.Context, c client.Reader, kind string, oldObj, newObj OwnableResourceWithMembership) error {
+	if reflect.DeepEqual(oldObj.GetOwners(), newObj.GetOwners()) {
+		return nil
+	}
+	errs, err := validation.ValidateOwners(ctx, c, newObj.GetOwners(), field.NewPath("spec", "owners"))
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(Kind(kind), newObj.GetName(), errs)
+	}
+	return nil
+}
+
 // checkMembership checks if the calling user is project member, and if not returns NotFound error
 func checkMembership(ctx context.Context, ownRes OwnableResourceWithMembership) error {
 	visible, err := isMember(ctx, ownRes)
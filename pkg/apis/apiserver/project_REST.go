@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/validation"
 )
 
 const (
@@ -49,6 +50,8 @@ type ProjectREST struct {
 	dynamicRI dynamic.NamespaceableResourceInterface
 	mapper    meta.RESTMapper
 	scheme    *runtime.Scheme
+
+	reservedNames *validation.ReservedNames
 }
 
 var ProjectRESTSingleton = &ProjectREST{}
@@ -92,6 +95,14 @@ func (p *ProjectREST) InjectScheme(scheme *runtime.Scheme) error {
 	return nil
 }
 
+func (p *ProjectREST) InjectReservedNames(reservedNames validation.ReservedNames) error {
+	if p.reservedNames != nil {
+		return fmt.Errorf("reservedNames already injected")
+	}
+	p.reservedNames = &reservedNames
+	return nil
+}
+
 var _ rest.Storage = (*ProjectREST)(nil)
 var _ rest.Scoper = (*ProjectREST)(nil)
 var _ rest.Getter = (*ProjectREST)(nil)
@@ -171,6 +182,9 @@ func (p *ProjectREST) Create(ctx context.Context, obj runtime.Object, createVali
 	if err := createValidation(ctx, obj); err != nil {
 		return nil, err
 	}
+	if err := checkReservedName(p.reservedNames, "Project", project.Name); err != nil {
+		return nil, err
+	}
 
 	// Here we're not using checkOwnership since we're returning different error.
 	// User should always include himself/herself in the Owners list, otherwise, we return BadRequest error to
@@ -183,6 +197,9 @@ func (p *ProjectREST) Create(ctx context.Context, obj runtime.Object, createVali
 	if !isOwner {
 		return nil, apierrors.NewBadRequest("cannot create project you're not the owner of")
 	}
+	if err := checkProjectCreation(ctx, p.client, p.scheme, project); err != nil {
+		return nil, err
+	}
 	u, err := ConvertToUnstructuredStorageV1Alpha1Project(project, p.scheme)
 	if err != nil {
 		return nil, err
@@ -236,6 +253,9 @@ func (p *ProjectREST) Update(ctx context.Context, name string, objInfo rest.Upda
 	if err := updateValidation(ctx, newObj, oldObj); err != nil {
 		return nil, false, err
 	}
+	if err := checkOwnersUpdate(ctx, p.client, "Project", oldObj, newObj.(*Project)); err != nil {
+		return nil, false, err
+	}
 
 	u, err := ConvertToUnstructuredStorageV1Alpha1Project(newObj.(*Project), p.scheme)
 	if err != nil {
@@ -273,24 +293,59 @@ func (p *ProjectREST) Delete(ctx context.Context, name string, deleteValidation
 	return obj, false, err
 }
 
+// DeleteCollection deletes the Projects, that are visible to and owned by the calling user, one by one.
+// Failures are reported per Project, the returned list contains the Projects that have been deleted.
 func (p *ProjectREST) DeleteCollection(ctx context.Context, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions, listOptions *internalversion.ListOptions) (runtime.Object, error) {
-	projects, err := p.List(ctx, listOptions)
+	opts := &metav1.ListOptions{}
+	if err := p.scheme.Convert(listOptions, opts, nil); err != nil {
+		return nil, err
+	}
+	uProjects, err := p.dynamicRI.Namespace(request.NamespaceValue(ctx)).List(ctx, *opts)
 	if err != nil {
 		return nil, err
 	}
-	for _, project := range projects.(*ProjectList).Items {
-		if err := checkOwnership(ctx, &project); err != nil {
+
+	deleted := &ProjectList{}
+	deleted.SetResourceVersion(uProjects.GetResourceVersion())
+	var (
+		deletedNames []string
+		failures     []metav1.StatusCause
+	)
+	for i := range uProjects.Items {
+		project, err := ConvertFromUnstructuredStorageV1Alpha1Project(&uProjects.Items[i], p.scheme)
+		if err != nil {
+			return nil, err
+		}
+		visible, err := isMember(ctx, project)
+		if err != nil {
 			return nil, err
 		}
+		if !visible {
+			continue
+		}
+		if err := p.deleteCollectionItem(ctx, project, deleteValidation, options); err != nil {
+			if !apierrors.IsNotFound(err) {
+				failures = append(failures, deleteCollectionFailure(project.Namespace+"/"+project.Name, err))
+			}
+			continue
+		}
+		deleted.Items = append(deleted.Items, *project)
+		deletedNames = append(deletedNames, project.Namespace+"/"+project.Name)
 	}
-	opts := &metav1.ListOptions{}
-	if err := p.scheme.Convert(listOptions, opts, nil); err != nil {
-		return nil, err
+	if len(failures) > 0 {
+		return nil, newDeleteCollectionError(Resource(externalProjectResource), deletedNames, failures)
 	}
-	if err := p.dynamicRI.Namespace(request.NamespaceValue(ctx)).DeleteCollection(ctx, *options, *opts); err != nil {
-		return nil, err
+	return deleted, nil
+}
+
+func (p *ProjectREST) deleteCollectionItem(ctx context.Context, project *Project, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) error {
+	if err := deleteValidation(ctx, project); err != nil {
+		return err
+	}
+	if err := checkOwnership(ctx, project); err != nil {
+		return err
 	}
-	return projects, nil
+	return p.dynamicRI.Namespace(project.Namespace).Delete(ctx, project.Name, deleteCollectionItemOptions(options, project.UID))
 }
 
 func (p *ProjectREST) Watch(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
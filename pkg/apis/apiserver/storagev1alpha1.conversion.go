@@ -35,6 +35,9 @@ func Corev1alpha1RegisterConversion(scheme *runtime.Scheme) error {
 		if err := Convert_apiserver_Organization_To_v1alpha1_Organization(in, out, scope); err != nil {
 			return err
 		}
+		// The user facing name is stored as alias label, as the storage Organization uses a generated name.
+		out.Labels = copyLabels(in.Labels)
+		out.Labels[storagev1alpha1.OrganizationNameLabel] = in.Name
 		for i := range out.ManagedFields {
 			out.ManagedFields[i].APIVersion = storagev1alpha1.GroupVersion.String()
 		}
@@ -48,6 +51,13 @@ func Corev1alpha1RegisterConversion(scheme *runtime.Scheme) error {
 		if err := Convert_v1alpha1_Organization_To_apiserver_Organization(in, out, scope); err != nil {
 			return err
 		}
+		out.Name = in.Alias()
+		out.GenerateName = ""
+		out.Labels = copyLabels(in.Labels)
+		delete(out.Labels, storagev1alpha1.OrganizationNameLabel)
+		if len(out.Labels) == 0 {
+			out.Labels = nil
+		}
 		for i := range out.ManagedFields {
 			out.ManagedFields[i].APIVersion = schema.GroupVersion{
 				Group:   SchemeGroupVersion.Group,
@@ -89,3 +99,12 @@ func Corev1alpha1RegisterConversion(scheme *runtime.Scheme) error {
 	}
 	return nil
 }
+
+// copyLabels returns a copy of the given labels, so they can be modified without touching the source object.
+func copyLabels(labels map[string]string) map[string]string {
+	out := make(map[string]string, len(labels))
+	for k, v := range labels {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	"k8c.io/bulward/pkg/templates"
+)
+
+// TemplatePreviewREST computes the preview of role templates, TemplatePreviews are not stored.
+// +k8s:deepcopy-gen=false
+type TemplatePreviewREST struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+var TemplatePreviewRESTSingleton = &TemplatePreviewREST{}
+
+func NewTemplatePreviewREST(_ generic.RESTOptionsGetter) rest.Storage {
+	return TemplatePreviewRESTSingleton
+}
+
+var _ inject.Client = (*TemplatePreviewREST)(nil)
+var _ inject.Scheme = (*TemplatePreviewREST)(nil)
+
+func (t *TemplatePreviewREST) InjectClient(c client.Client) error {
+	if t.client != nil {
+		return fmt.Errorf("client already injected")
+	}
+	t.client = c
+	return nil
+}
+
+func (t *TemplatePreviewREST) InjectScheme(scheme *runtime.Scheme) error {
+	if t.scheme != nil {
+		return fmt.Errorf("scheme already injected")
+	}
+	t.scheme = scheme
+	return nil
+}
+
+var _ rest.Storage = (*TemplatePreviewREST)(nil)
+var _ rest.Scoper = (*TemplatePreviewREST)(nil)
+var _ rest.Creater = (*TemplatePreviewREST)(nil)
+
+func (t *TemplatePreviewREST) New() runtime.Object {
+	return &TemplatePreview{}
+}
+
+func (t *TemplatePreviewREST) NamespaceScoped() bool {
+	return false
+}
+
+// Create returns the preview of the role template in the TemplatePreview status without applying the template.
+// The calling user must be allowed to create or update the previewed template
+// and to read the Roles and RoleBindings the preview discloses.
+func (t *TemplatePreviewREST) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	preview := obj.(*TemplatePreview)
+	if preview.Name == "" && preview.GenerateName != "" {
+		preview.Name = names.SimpleNameGenerator.GenerateName(preview.GenerateName)
+	}
+	if createValidation != nil {
+		if err := createValidation(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+	template, err := t.decodeTemplate(preview)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.checkTemplateAccess(ctx, template); err != nil {
+		return nil, err
+	}
+	status, err := templates.Preview(ctx, t.client, t.scheme, template)
+	if err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("previewing role template: %w", err))
+	}
+	if err := t.checkPreviewAccess(ctx, template, status); err != nil {
+		return nil, err
+	}
+	preview.Status = *status
+	return preview, nil
+}
+
+// decodeTemplate decodes the OrganizationRoleTemplate or ProjectRoleTemplate of the TemplatePreview.
+func (t *TemplatePreviewREST) decodeTemplate(preview *TemplatePreview) (runtime.Object, error) {
+	templatePath := field.NewPath("spec", "template")
+	invalid := func(detail string) error {
+		return apierrors.NewInvalid(Kind("TemplatePreview"), preview.Name, field.ErrorList{
+			field.Invalid(templatePath, "", detail),
+		})
+	}
+	if len(preview.Spec.Template.Raw) == 0 {
+		return nil, apierrors.NewInvalid(Kind("TemplatePreview"), preview.Name, field.ErrorList{
+			field.Required(templatePath, "an OrganizationRoleTemplate or ProjectRoleTemplate is required"),
+		})
+	}
+	template, _, err := serializer.NewCodecFactory(t.scheme).UniversalDeserializer().Decode(preview.Spec.Template.Raw, nil, nil)
+	if err != nil {
+		return nil, invalid(err.Error())
+	}
+	switch template := template.(type) {
+	case *corev1alpha1.OrganizationRoleTemplate:
+		return template, nil
+	case *corev1alpha1.ProjectRoleTemplate:
+		if template.Namespace == "" {
+			return nil, invalid("metadata.namespace of ProjectRoleTemplate is required")
+		}
+		return template, nil
+	default:
+		return nil, invalid(fmt.Sprintf("unsupported kind %s, must be OrganizationRoleTemplate or ProjectRoleTemplate", template.GetObjectKind().GroupVersionKind().Kind))
+	}
+}
+
+// checkTemplateAccess checks that the calling user may apply the previewed template,
+// so the preview doesn't disclose Organizations and Projects the user can't target anyway.
+func (t *TemplatePreviewREST) checkTemplateAccess(ctx context.Context, template runtime.Object) error {
+	accessor := template.(metav1.Object)
+	var resource string
+	switch template.(type) {
+	case *corev1alpha1.OrganizationRoleTemplate:
+		resource = "organizationroletemplates"
+	case *corev1alpha1.ProjectRoleTemplate:
+		resource = "projectroletemplates"
+	}
+
+	verb := "update"
+	existing := template.DeepCopyObject()
+	if err := t.client.Get(ctx, types.NamespacedName{Name: accessor.GetName(), Namespace: accessor.GetNamespace()}, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		verb = "create"
+	}
+	allowed, err := isAuthorized(ctx, t.client, &authorizationv1.ResourceAttributes{
+		Namespace: accessor.GetNamespace(),
+		Verb:      verb,
+		Group:     corev1alpha1.GroupVersion.Group,
+		Resource:  resource,
+		Name:      accessor.GetName(),
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return apierrors.NewForbidden(
+			Resource("templatepreviews"),
+			accessor.GetName(),
+			fmt.Errorf("%s %s is not allowed", verb, resource),
+		)
+	}
+	return nil
+}
+
+// checkPreviewAccess checks that the calling user may read the Roles and RoleBindings in all namespaces of the preview,
+// as the preview is computed by the manager and contains their rules and subjects.
+// Rules copied from a referenced ClusterRole require access to the ClusterRole as well.
+func (t *TemplatePreviewREST) checkPreviewAccess(ctx context.Context, template runtime.Object, status *corev1alpha1.TemplatePreviewStatus) error {
+	var clusterRoleRef *corev1alpha1.ObjectReference
+	switch template := template.(type) {
+	case *corev1alpha1.OrganizationRoleTemplate:
+		clusterRoleRef = template.Spec.ClusterRoleRef
+	case *corev1alpha1.ProjectRoleTemplate:
+		clusterRoleRef = template.Spec.ClusterRoleRef
+	}
+	var attributes []*authorizationv1.ResourceAttributes
+	if clusterRoleRef != nil {
+		attributes = append(attributes, &authorizationv1.ResourceAttributes{
+			Verb:     "get",
+			Group:    rbacv1.GroupName,
+			Resource: "clusterroles",
+			Name:     clusterRoleRef.Name,
+		})
+	}
+	namespaces := sets.NewString()
+	for _, target := range status.Targets {
+		namespaces.Insert(target.Namespace)
+	}
+	for _, change := range status.Changes {
+		namespaces.Insert(change.Namespace)
+	}
+	for _, namespace := range namespaces.List() {
+		for _, resource := range []string{"roles", "rolebindings"} {
+			attributes = append(attributes, &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Group:     rbacv1.GroupName,
+				Resource:  resource,
+			})
+		}
+	}
+
+	accessor := template.(metav1.Object)
+	for _, attrs := range attributes {
+		allowed, err := isAuthorized(ctx, t.client, attrs)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			continue
+		}
+		if attrs.Namespace == "" {
+			return apierrors.NewForbidden(Resource("templatepreviews"), accessor.GetName(),
+				fmt.Errorf("%s %s %s is not allowed", attrs.Verb, attrs.Resource, attrs.Name))
+		}
+		return apierrors.NewForbidden(Resource("templatepreviews"), accessor.GetName(),
+			fmt.Errorf("%s %s in namespace %s is not allowed", attrs.Verb, attrs.Resource, attrs.Namespace))
+	}
+	return nil
+}
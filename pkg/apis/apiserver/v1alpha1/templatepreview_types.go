@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=create
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TemplatePreview previews the targets and RBAC changes of an OrganizationRoleTemplate or ProjectRoleTemplate without applying it.
+// TemplatePreviews are not stored, the preview is returned in the status of the created object.
+// +k8s:openapi-gen=true
+// +protobuf=false
+// +resource:path=templatepreviews,rest=TemplatePreviewREST
+type TemplatePreview struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   corev1alpha1.TemplatePreviewSpec   `json:"spec,omitempty"`
+	Status corev1alpha1.TemplatePreviewStatus `json:"status,omitempty"`
+}
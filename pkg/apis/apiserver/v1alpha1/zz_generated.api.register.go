@@ -34,6 +34,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&OrganizationList{},
 		&Project{},
 		&ProjectList{},
+		&TemplatePreview{},
+		&TemplatePreviewList{},
 	)
 	return nil
 }
@@ -42,6 +44,7 @@ var (
 	ApiVersion = builders.NewApiVersion("apiserver.bulward.io", "v1alpha1").WithResources(
 		apiserver.ApiserverOrganizationStorage,
 		apiserver.ApiserverProjectStorage,
+		apiserver.ApiserverTemplatePreviewStorage,
 	)
 
 	// Required by code generated by go2idl
@@ -88,3 +91,11 @@ type ProjectList struct {
 	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
 	Items           []Project `json:"items" protobuf:"bytes,2,opt,name=items"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TemplatePreviewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TemplatePreview `json:"items"`
+}
@@ -76,6 +76,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TemplatePreview)(nil), (*apiserver.TemplatePreview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TemplatePreview_To_apiserver_TemplatePreview(a.(*TemplatePreview), b.(*apiserver.TemplatePreview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*apiserver.TemplatePreview)(nil), (*TemplatePreview)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_apiserver_TemplatePreview_To_v1alpha1_TemplatePreview(a.(*apiserver.TemplatePreview), b.(*TemplatePreview), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TemplatePreviewList)(nil), (*apiserver.TemplatePreviewList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TemplatePreviewList_To_apiserver_TemplatePreviewList(a.(*TemplatePreviewList), b.(*apiserver.TemplatePreviewList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*apiserver.TemplatePreviewList)(nil), (*TemplatePreviewList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_apiserver_TemplatePreviewList_To_v1alpha1_TemplatePreviewList(a.(*apiserver.TemplatePreviewList), b.(*TemplatePreviewList), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -170,3 +190,49 @@ func autoConvert_apiserver_ProjectList_To_v1alpha1_ProjectList(in *apiserver.Pro
 func Convert_apiserver_ProjectList_To_v1alpha1_ProjectList(in *apiserver.ProjectList, out *ProjectList, s conversion.Scope) error {
 	return autoConvert_apiserver_ProjectList_To_v1alpha1_ProjectList(in, out, s)
 }
+
+func autoConvert_v1alpha1_TemplatePreview_To_apiserver_TemplatePreview(in *TemplatePreview, out *apiserver.TemplatePreview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return nil
+}
+
+// Convert_v1alpha1_TemplatePreview_To_apiserver_TemplatePreview is an autogenerated conversion function.
+func Convert_v1alpha1_TemplatePreview_To_apiserver_TemplatePreview(in *TemplatePreview, out *apiserver.TemplatePreview, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TemplatePreview_To_apiserver_TemplatePreview(in, out, s)
+}
+
+func autoConvert_apiserver_TemplatePreview_To_v1alpha1_TemplatePreview(in *apiserver.TemplatePreview, out *TemplatePreview, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return nil
+}
+
+// Convert_apiserver_TemplatePreview_To_v1alpha1_TemplatePreview is an autogenerated conversion function.
+func Convert_apiserver_TemplatePreview_To_v1alpha1_TemplatePreview(in *apiserver.TemplatePreview, out *TemplatePreview, s conversion.Scope) error {
+	return autoConvert_apiserver_TemplatePreview_To_v1alpha1_TemplatePreview(in, out, s)
+}
+
+func autoConvert_v1alpha1_TemplatePreviewList_To_apiserver_TemplatePreviewList(in *TemplatePreviewList, out *apiserver.TemplatePreviewList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]apiserver.TemplatePreview)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_TemplatePreviewList_To_apiserver_TemplatePreviewList is an autogenerated conversion function.
+func Convert_v1alpha1_TemplatePreviewList_To_apiserver_TemplatePreviewList(in *TemplatePreviewList, out *apiserver.TemplatePreviewList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TemplatePreviewList_To_apiserver_TemplatePreviewList(in, out, s)
+}
+
+func autoConvert_apiserver_TemplatePreviewList_To_v1alpha1_TemplatePreviewList(in *apiserver.TemplatePreviewList, out *TemplatePreviewList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]TemplatePreview)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_apiserver_TemplatePreviewList_To_v1alpha1_TemplatePreviewList is an autogenerated conversion function.
+func Convert_apiserver_TemplatePreviewList_To_v1alpha1_TemplatePreviewList(in *apiserver.TemplatePreviewList, out *TemplatePreviewList, s conversion.Scope) error {
+	return autoConvert_apiserver_TemplatePreviewList_To_v1alpha1_TemplatePreviewList(in, out, s)
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apiserver/pkg/registry/rest"
 	"sigs.k8s.io/apiserver-builder-alpha/pkg/builders"
 
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
 )
 
@@ -45,6 +46,12 @@ var (
 		func() runtime.Object { return &ProjectList{} }, // Register versioned resource list
 		NewProjectREST,
 	)
+	ApiserverTemplatePreviewStorage = builders.NewApiResourceWithStorage( // Resource status endpoint
+		InternalTemplatePreview,
+		func() runtime.Object { return &TemplatePreview{} },     // Register versioned resource
+		func() runtime.Object { return &TemplatePreviewList{} }, // Register versioned resource list
+		NewTemplatePreviewREST,
+	)
 	InternalOrganization = builders.NewInternalResource(
 		"organizations",
 		"Organization",
@@ -69,12 +76,19 @@ var (
 		func() runtime.Object { return &Project{} },
 		func() runtime.Object { return &ProjectList{} },
 	)
+	InternalTemplatePreview = builders.NewInternalResource(
+		"templatepreviews",
+		"TemplatePreview",
+		func() runtime.Object { return &TemplatePreview{} },
+		func() runtime.Object { return &TemplatePreviewList{} },
+	)
 	// Registered resources and subresources
 	ApiVersion = builders.NewApiGroup("apiserver.bulward.io").WithKinds(
 		InternalOrganization,
 		InternalOrganizationStatus,
 		InternalProject,
 		InternalProjectStatus,
+		InternalTemplatePreview,
 	)
 
 	// Required by code generated by go2idl
@@ -121,6 +135,17 @@ type Project struct {
 	Status storagev1alpha1.ProjectStatus
 }
 
+// +genclient
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TemplatePreview struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Spec   corev1alpha1.TemplatePreviewSpec
+	Status corev1alpha1.TemplatePreviewStatus
+}
+
 //
 // Organization Functions and Structs
 //
@@ -360,3 +385,118 @@ func (s *storageProject) DeleteProject(ctx context.Context, id string) (bool, er
 	_, sync, err := st.Delete(ctx, id, nil, &metav1.DeleteOptions{})
 	return sync, err
 }
+
+//
+// TemplatePreview Functions and Structs
+//
+// +k8s:deepcopy-gen=false
+type TemplatePreviewStrategy struct {
+	builders.DefaultStorageStrategy
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TemplatePreviewList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+	Items []TemplatePreview
+}
+
+func (TemplatePreview) NewStatus() interface{} {
+	return corev1alpha1.TemplatePreviewStatus{}
+}
+
+func (pc *TemplatePreview) GetStatus() interface{} {
+	return pc.Status
+}
+
+func (pc *TemplatePreview) SetStatus(s interface{}) {
+	pc.Status = s.(corev1alpha1.TemplatePreviewStatus)
+}
+
+func (pc *TemplatePreview) GetSpec() interface{} {
+	return pc.Spec
+}
+
+func (pc *TemplatePreview) SetSpec(s interface{}) {
+	pc.Spec = s.(corev1alpha1.TemplatePreviewSpec)
+}
+
+func (pc *TemplatePreview) GetObjectMeta() *metav1.ObjectMeta {
+	return &pc.ObjectMeta
+}
+
+func (pc *TemplatePreview) SetGeneration(generation int64) {
+	pc.ObjectMeta.Generation = generation
+}
+
+func (pc TemplatePreview) GetGeneration() int64 {
+	return pc.ObjectMeta.Generation
+}
+
+// Registry is an interface for things that know how to store TemplatePreview.
+// +k8s:deepcopy-gen=false
+type TemplatePreviewRegistry interface {
+	ListTemplatePreviews(ctx context.Context, options *internalversion.ListOptions) (*TemplatePreviewList, error)
+	GetTemplatePreview(ctx context.Context, id string, options *metav1.GetOptions) (*TemplatePreview, error)
+	CreateTemplatePreview(ctx context.Context, id *TemplatePreview) (*TemplatePreview, error)
+	UpdateTemplatePreview(ctx context.Context, id *TemplatePreview) (*TemplatePreview, error)
+	DeleteTemplatePreview(ctx context.Context, id string) (bool, error)
+}
+
+// NewRegistry returns a new Registry interface for the given Storage. Any mismatched types will panic.
+func NewTemplatePreviewRegistry(sp builders.StandardStorageProvider) TemplatePreviewRegistry {
+	return &storageTemplatePreview{sp}
+}
+
+// Implement Registry
+// storage puts strong typing around storage calls
+// +k8s:deepcopy-gen=false
+type storageTemplatePreview struct {
+	builders.StandardStorageProvider
+}
+
+func (s *storageTemplatePreview) ListTemplatePreviews(ctx context.Context, options *internalversion.ListOptions) (*TemplatePreviewList, error) {
+	if options != nil && options.FieldSelector != nil && !options.FieldSelector.Empty() {
+		return nil, fmt.Errorf("field selector not supported yet")
+	}
+	st := s.GetStandardStorage()
+	obj, err := st.List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*TemplatePreviewList), err
+}
+
+func (s *storageTemplatePreview) GetTemplatePreview(ctx context.Context, id string, options *metav1.GetOptions) (*TemplatePreview, error) {
+	st := s.GetStandardStorage()
+	obj, err := st.Get(ctx, id, options)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*TemplatePreview), nil
+}
+
+func (s *storageTemplatePreview) CreateTemplatePreview(ctx context.Context, object *TemplatePreview) (*TemplatePreview, error) {
+	st := s.GetStandardStorage()
+	obj, err := st.Create(ctx, object, nil, &metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*TemplatePreview), nil
+}
+
+func (s *storageTemplatePreview) UpdateTemplatePreview(ctx context.Context, object *TemplatePreview) (*TemplatePreview, error) {
+	st := s.GetStandardStorage()
+	obj, _, err := st.Update(ctx, object.Name, rest.DefaultUpdatedObjectInfo(object), nil, nil, false, &metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*TemplatePreview), nil
+}
+
+func (s *storageTemplatePreview) DeleteTemplatePreview(ctx context.Context, id string) (bool, error) {
+	st := s.GetStandardStorage()
+	_, sync, err := st.Delete(ctx, id, nil, &metav1.DeleteOptions{})
+	return sync, err
+}
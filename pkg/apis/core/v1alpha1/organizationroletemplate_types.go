@@ -29,9 +29,53 @@ type OrganizationRoleTemplateSpec struct {
 	// +kubebuilder:validation:MinItems=1
 	Scopes []RoleTemplateScope `json:"scopes"`
 	// BindTo defines the member types of the Organization that this OrganizationRoleTemplate will be bound to.
+	// Defaults to Owners, when the template is created.
 	BindTo []BindingType `json:"bindTo,omitempty"`
+	// OrganizationSelector selects the Organizations this OrganizationRoleTemplate is applied to.
+	// All Organizations are selected, if not set.
+	OrganizationSelector *metav1.LabelSelector `json:"organizationSelector,omitempty"`
+	// ProjectSelector selects the Projects of the selected Organizations this OrganizationRoleTemplate is applied to,
+	// if it has the Project scope. All Projects are selected, if not set.
+	ProjectSelector *metav1.LabelSelector `json:"projectSelector,omitempty"`
+	// RoleName is the name of the Role and RoleBinding created for every target,
+	// defaults to the name of the template prefixed with `organizationroletemplate:`, so it doesn't collide with other templates.
+	// Placeholders like `{{ .Organization.Name }}`, `{{ .Project.Name }}` and `{{ .Namespace }}` are rendered per target.
+	RoleName string `json:"roleName,omitempty"`
 	// Rules defines the Role that this OrganizationRoleTemplate refers to.
-	Rules []rbacv1.PolicyRule `json:"rules"`
+	// Placeholders in the ResourceNames of the rules are rendered per target, like the ones of RoleName.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// ClusterRoleRef references an existing ClusterRole, whose rules are copied into the Role of every target in addition to Rules.
+	// The Roles are updated, when the rules of the ClusterRole change, so aggregated ClusterRoles can be used as well.
+	ClusterRoleRef *ObjectReference `json:"clusterRoleRef,omitempty"`
+	// CRDRules generates additional rules for the installed CustomResourceDefinitions matching the selectors.
+	// The Roles are updated, when matching CustomResourceDefinitions are added or removed.
+	CRDRules []RoleTemplateCRDRules `json:"crdRules,omitempty"`
+	// Includes pulls in the rules of other role templates, including their referenced ClusterRoles, generated rules and includes.
+	// Placeholders of included rules are rendered for the targets of this template.
+	Includes []RoleTemplateReference `json:"includes,omitempty"`
+	// RolloutStrategy stages the rollout of changes to the selected Organizations.
+	// Changes are rolled out to all Organizations at once, if not set.
+	RolloutStrategy *OrganizationRoleTemplateRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// OrganizationRoleTemplateRolloutStrategy describes how changes of an OrganizationRoleTemplate are rolled out to Organizations.
+// Organizations, that have not been updated yet, keep the Roles and RoleBindings of the previous revision.
+// Changes of a referenced ClusterRole or of matching CustomResourceDefinitions are not staged.
+type OrganizationRoleTemplateRolloutStrategy struct {
+	// Canary selects the Organizations that are updated first.
+	// Other Organizations are only updated, when all canary Organizations are.
+	Canary *metav1.LabelSelector `json:"canary,omitempty"`
+	// BatchSize is the maximum number of Organizations updated at once.
+	// All outdated Organizations are updated at once, if not set.
+	// +kubebuilder:validation:Minimum=1
+	BatchSize int `json:"batchSize,omitempty"`
+	// BatchInterval is the minimum time between two batches.
+	BatchInterval metav1.Duration `json:"batchInterval,omitempty"`
+	// MaxUnavailable is the number of failed targets the rollout tolerates, before it halts.
+	// +kubebuilder:validation:Minimum=0
+	MaxUnavailable int `json:"maxUnavailable,omitempty"`
+	// Paused stops the rollout to further Organizations, until it's resumed.
+	Paused bool `json:"paused,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Organization;Project
@@ -64,10 +108,44 @@ type OrganizationRoleTemplateStatus struct {
 	// is a mechanism to map conditions to strings when printing the property.
 	// This is only for display purpose, for everything else use conditions.
 	Phase OrganizationRoleTemplatePhaseType `json:"phase,omitempty"`
-	// Targets holds different targets(Organization, Project) that this OrganizationRoleTemplate targets to.
-	Targets []RoleTemplateTarget `json:"targets,omitempty"`
+	// Rollout summarizes the rollout of the Roles and RoleBindings of this OrganizationRoleTemplate to its targets.
+	Rollout RoleTemplateRollout `json:"rollout,omitempty"`
+	// StagedRollout reports the progress of the staged rollout, if the OrganizationRoleTemplate has a RolloutStrategy.
+	StagedRollout *OrganizationRoleTemplateStagedRollout `json:"stagedRollout,omitempty"`
 }
 
+// OrganizationRoleTemplateStagedRollout reports the progress of a staged rollout.
+type OrganizationRoleTemplateStagedRollout struct {
+	// Revision of the OrganizationRoleTemplate that is rolled out.
+	Revision string `json:"revision"`
+	// Phase of the staged rollout.
+	Phase StagedRolloutPhase `json:"phase"`
+	// Organizations is the number of selected Organizations.
+	Organizations int `json:"organizations"`
+	// UpdatedOrganizations is the number of Organizations that have the current revision.
+	UpdatedOrganizations int `json:"updatedOrganizations"`
+	// LastBatchTime is the time the last batch of Organizations was updated.
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+	// FailedOrganizations are the Organizations reached by the rollout, that failed to update.
+	// They are retried before further batches, and count towards the MaxUnavailable targets.
+	FailedOrganizations []string `json:"failedOrganizations,omitempty"`
+}
+
+// StagedRolloutPhase is the phase of a staged rollout.
+// +kubebuilder:validation:Enum=Progressing;Paused;Halted;Complete
+type StagedRolloutPhase string
+
+const (
+	// StagedRolloutProgressing means the rollout updates further Organizations batch by batch.
+	StagedRolloutProgressing StagedRolloutPhase = "Progressing"
+	// StagedRolloutPaused means the rollout was paused via the RolloutStrategy.
+	StagedRolloutPaused StagedRolloutPhase = "Paused"
+	// StagedRolloutHalted means the rollout stopped, because more targets failed than tolerated.
+	StagedRolloutHalted StagedRolloutPhase = "Halted"
+	// StagedRolloutComplete means all Organizations have the current revision.
+	StagedRolloutComplete StagedRolloutPhase = "Complete"
+)
+
 // OrganizationRoleTemplatePhaseType represents all conditions as a single string for printing by using kubectl commands.
 // +kubebuilder:validation:Ready;NotReady;Unknown;Terminating
 type OrganizationRoleTemplatePhaseType string
@@ -82,6 +160,12 @@ const (
 
 const (
 	OrganizationRoleTemplateTerminatingReason = "Deleting"
+	// OrganizationRoleTemplateRolloutFailedReason is used, when the rollout to some targets failed.
+	OrganizationRoleTemplateRolloutFailedReason = "RolloutFailed"
+	// OrganizationRoleTemplateNameConflictReason is used, when Roles or RoleBindings of the OrganizationRoleTemplate already exist and belong to someone else.
+	OrganizationRoleTemplateNameConflictReason = "NameConflict"
+	// OrganizationRoleTemplateIncludeCycleReason is used, when included role templates include each other.
+	OrganizationRoleTemplateIncludeCycleReason = "CycleDetected"
 )
 
 // updatePhase updates the phase property based on the current conditions.
@@ -111,17 +195,23 @@ func (s *OrganizationRoleTemplateStatus) updatePhase() {
 }
 
 // OrganizationRoleTemplateConditionType represents a OrganizationRoleTemplateCondition value.
-// +kubebuilder:validation:Ready
+// +kubebuilder:validation:Ready;Degraded;Conflict;IncludeCycle
 type OrganizationRoleTemplateConditionType string
 
 const (
 	// OrganizationRoleTemplateReady represents a OrganizationRoleTemplate condition is in ready state.
 	OrganizationRoleTemplateReady OrganizationRoleTemplateConditionType = "Ready"
+	// OrganizationRoleTemplateDegraded represents a OrganizationRoleTemplate condition, where the rollout to some targets failed.
+	OrganizationRoleTemplateDegraded OrganizationRoleTemplateConditionType = "Degraded"
+	// OrganizationRoleTemplateConflict represents a OrganizationRoleTemplate condition, where Roles or RoleBindings of targets are owned by someone else.
+	OrganizationRoleTemplateConflict OrganizationRoleTemplateConditionType = "Conflict"
+	// OrganizationRoleTemplateIncludeCycle represents a OrganizationRoleTemplate condition, where the included role templates include each other.
+	OrganizationRoleTemplateIncludeCycle OrganizationRoleTemplateConditionType = "IncludeCycle"
 )
 
 // OrganizationRoleTemplateCondition contains details for the current condition of this OrganizationRoleTemplate.
 type OrganizationRoleTemplateCondition struct {
-	// Type is the type of the OrganizationRoleTemplate condition, currently ('Ready').
+	// Type is the type of the OrganizationRoleTemplate condition, currently ('Ready', 'Degraded', 'Conflict', 'IncludeCycle').
 	Type OrganizationRoleTemplateConditionType `json:"type"`
 	// Status is the status of the condition, one of ('True', 'False', 'Unknown').
 	Status ConditionStatus `json:"status"`
@@ -215,6 +305,11 @@ func (s *OrganizationRoleTemplate) HasScope(organizationRoleScope RoleTemplateSc
 	return false
 }
 
+// DefaultRoleName returns the name of the Role and RoleBinding of the OrganizationRoleTemplate, if no RoleName is set.
+func (s *OrganizationRoleTemplate) DefaultRoleName() string {
+	return OrganizationRoleTemplateRolePrefix + s.Name
+}
+
 func (s *OrganizationRoleTemplate) HasBinding(bindTo BindingType) bool {
 	for _, b := range s.Spec.BindTo {
 		if b == bindTo {
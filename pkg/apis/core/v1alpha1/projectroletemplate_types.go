@@ -26,11 +26,26 @@ type ProjectRoleTemplateSpec struct {
 	// Metadata contains additional human readable ProjectRoleTemplate details.
 	Metadata *ProjectRoleTemplateMetadata `json:"metadata,omitempty"`
 	// BindTo defines the member types of the Project that this ProjectRoleTemplate will be bound to.
+	// Defaults to Owners, when the template is created.
 	BindTo []BindingType `json:"bindTo,omitempty"`
 	// ProjectSelector selects applicable target Projects.
 	ProjectSelector *metav1.LabelSelector `json:"projectSelector,omitempty"`
+	// RoleName is the name of the Role and RoleBinding created for every target,
+	// defaults to the name of the template prefixed with `projectroletemplate:`, so it doesn't collide with other templates.
+	// Placeholders like `{{ .Organization.Name }}`, `{{ .Project.Name }}` and `{{ .Namespace }}` are rendered per target.
+	RoleName string `json:"roleName,omitempty"`
 	// Rules creates RBAC Roles that will be managed by this ProjectRoleTemplate.
-	Rules []rbacv1.PolicyRule `json:"rules"`
+	// Placeholders in the ResourceNames of the rules are rendered per target, like the ones of RoleName.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// ClusterRoleRef references an existing ClusterRole, whose rules are copied into the Role of every target in addition to Rules.
+	// The Roles are updated, when the rules of the ClusterRole change, so aggregated ClusterRoles can be used as well.
+	ClusterRoleRef *ObjectReference `json:"clusterRoleRef,omitempty"`
+	// CRDRules generates additional rules for the installed CustomResourceDefinitions matching the selectors.
+	// The Roles are updated, when matching CustomResourceDefinitions are added or removed.
+	CRDRules []RoleTemplateCRDRules `json:"crdRules,omitempty"`
+	// Includes pulls in the rules of other role templates, including their referenced ClusterRoles, generated rules and includes.
+	// Placeholders of included rules are rendered for the targets of this template.
+	Includes []RoleTemplateReference `json:"includes,omitempty"`
 }
 
 // ProjectRoleTemplateMetadata contains the metadata of the ProjectRoleTemplate.
@@ -55,8 +70,8 @@ type ProjectRoleTemplateStatus struct {
 	// is a mechanism to map conditions to strings when printing the property.
 	// This is only for display purpose, for everything else use conditions.
 	Phase ProjectRoleTemplatePhaseType `json:"phase,omitempty"`
-	// Targets holds different targets(Project, Project) that this ProjectRoleTemplate targets to.
-	Targets []RoleTemplateTarget `json:"targets,omitempty"`
+	// Rollout summarizes the rollout of the Roles and RoleBindings of this ProjectRoleTemplate to its targets.
+	Rollout RoleTemplateRollout `json:"rollout,omitempty"`
 }
 
 // ProjectRoleTemplatePhaseType represents all conditions as a single string for printing by using kubectl commands.
@@ -73,6 +88,12 @@ const (
 
 const (
 	ProjectRoleTemplateTerminatingReason = "Deleting"
+	// ProjectRoleTemplateRolloutFailedReason is used, when the rollout to some targets failed.
+	ProjectRoleTemplateRolloutFailedReason = "RolloutFailed"
+	// ProjectRoleTemplateNameConflictReason is used, when Roles or RoleBindings of the ProjectRoleTemplate already exist and belong to someone else.
+	ProjectRoleTemplateNameConflictReason = "NameConflict"
+	// ProjectRoleTemplateIncludeCycleReason is used, when included role templates include each other.
+	ProjectRoleTemplateIncludeCycleReason = "CycleDetected"
 )
 
 // updatePhase updates the phase property based on the current conditions.
@@ -102,17 +123,23 @@ func (s *ProjectRoleTemplateStatus) updatePhase() {
 }
 
 // ProjectRoleTemplateConditionType represents a ProjectRoleTemplateCondition value.
-// +kubebuilder:validation:Ready
+// +kubebuilder:validation:Ready;Degraded;Conflict;IncludeCycle
 type ProjectRoleTemplateConditionType string
 
 const (
 	// ProjectRoleTemplateReady represents a ProjectRoleTemplate condition is in ready state.
 	ProjectRoleTemplateReady ProjectRoleTemplateConditionType = "Ready"
+	// ProjectRoleTemplateDegraded represents a ProjectRoleTemplate condition, where the rollout to some targets failed.
+	ProjectRoleTemplateDegraded ProjectRoleTemplateConditionType = "Degraded"
+	// ProjectRoleTemplateConflict represents a ProjectRoleTemplate condition, where Roles or RoleBindings of targets are owned by someone else.
+	ProjectRoleTemplateConflict ProjectRoleTemplateConditionType = "Conflict"
+	// ProjectRoleTemplateIncludeCycle represents a ProjectRoleTemplate condition, where the included role templates include each other.
+	ProjectRoleTemplateIncludeCycle ProjectRoleTemplateConditionType = "IncludeCycle"
 )
 
 // ProjectRoleTemplateCondition contains details for the current condition of this ProjectRoleTemplate.
 type ProjectRoleTemplateCondition struct {
-	// Type is the type of the ProjectRoleTemplate condition, currently ('Ready').
+	// Type is the type of the ProjectRoleTemplate condition, currently ('Ready', 'Degraded', 'Conflict', 'IncludeCycle').
 	Type ProjectRoleTemplateConditionType `json:"type"`
 	// Status is the status of the condition, one of ('True', 'False', 'Unknown').
 	Status ConditionStatus `json:"status"`
@@ -196,6 +223,11 @@ func (s *ProjectRoleTemplate) IsReady() bool {
 	return false
 }
 
+// DefaultRoleName returns the name of the Role and RoleBinding of the ProjectRoleTemplate, if no RoleName is set.
+func (s *ProjectRoleTemplate) DefaultRoleName() string {
+	return ProjectRoleTemplateRolePrefix + s.Name
+}
+
 func (s *ProjectRoleTemplate) HasBinding(bindTo BindingType) bool {
 	for _, b := range s.Spec.BindTo {
 		if b == bindTo {
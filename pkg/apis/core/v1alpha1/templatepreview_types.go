@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TemplatePreviewSpec describes the role template to preview.
+// +k8s:openapi-gen=true
+type TemplatePreviewSpec struct {
+	// Template is the OrganizationRoleTemplate or ProjectRoleTemplate to preview, as it would be applied.
+	Template runtime.RawExtension `json:"template"`
+}
+
+// TemplatePreviewStatus describes the targets of the previewed role template
+// and the changes to its Roles and RoleBindings, that applying the template would cause.
+// +k8s:openapi-gen=true
+type TemplatePreviewStatus struct {
+	// Targets are the Organizations and Projects the role template would be rolled out to.
+	Targets []TemplatePreviewTarget `json:"targets,omitempty"`
+	// Changes are the Roles and RoleBindings that would be created, updated or deleted.
+	Changes []RBACChange `json:"changes,omitempty"`
+}
+
+// TemplatePreviewTarget is an Organization or Project the previewed role template would be rolled out to.
+// +k8s:openapi-gen=true
+type TemplatePreviewTarget struct {
+	// Kind of the target, "Organization" or "Project".
+	Kind string `json:"kind"`
+	// Name of the target.
+	Name string `json:"name"`
+	// Organization the target belongs to.
+	Organization string `json:"organization,omitempty"`
+	// Namespace of the target, the Role and RoleBinding are created in.
+	Namespace string `json:"namespace"`
+	// Message is the human readable message indicating why the role template can't be rendered for this target.
+	// Existing Roles and RoleBindings of the role template in the namespace are kept in this case.
+	Message string `json:"message,omitempty"`
+}
+
+// RBACChangeType is the type of change to a Role or RoleBinding.
+type RBACChangeType string
+
+const (
+	RBACChangeCreate RBACChangeType = "Create"
+	RBACChangeUpdate RBACChangeType = "Update"
+	RBACChangeDelete RBACChangeType = "Delete"
+)
+
+// RBACChange describes a change to a single Role or RoleBinding.
+// +k8s:openapi-gen=true
+type RBACChange struct {
+	// Type of the change.
+	Type RBACChangeType `json:"type"`
+	// Kind of the changed object, "Role" or "RoleBinding".
+	Kind string `json:"kind"`
+	// Namespace of the changed object.
+	Namespace string `json:"namespace"`
+	// Name of the changed object.
+	Name string `json:"name"`
+	// Current state of the object, unset if it would be created.
+	Current *RBACObjectState `json:"current,omitempty"`
+	// Desired state of the object, unset if it would be deleted.
+	Desired *RBACObjectState `json:"desired,omitempty"`
+}
+
+// RBACObjectState holds the relevant parts of a Role or RoleBinding.
+// +k8s:openapi-gen=true
+type RBACObjectState struct {
+	// Rules of a Role.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// RoleRef of a RoleBinding.
+	RoleRef *rbacv1.RoleRef `json:"roleRef,omitempty"`
+	// Subjects of a RoleBinding.
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
+}
@@ -16,6 +16,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ConditionStatus represents a condition's status.
 // +kubebuilder:validation:True;False;Unknown
 type ConditionStatus string
@@ -60,4 +64,135 @@ type RoleTemplateTarget struct {
 	Name string `json:"name"`
 	// ObservedGeneration is the most recent generation observed for this Target by the controller.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// State of the rollout to this Target.
+	State RoleTemplateTargetState `json:"state,omitempty"`
+	// Message is the human readable message indicating why the rollout to this Target failed.
+	Message string `json:"message,omitempty"`
+	// LastAttemptTime is the time the rollout to this Target started failing with the current Message.
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+}
+
+// RoleTemplateReference references another role template, whose rules are included.
+type RoleTemplateReference struct {
+	// Kind of the referenced role template, defaults to OrganizationRoleTemplate.
+	// OrganizationRoleTemplates can only include OrganizationRoleTemplates,
+	// ProjectRoleTemplates can include OrganizationRoleTemplates and ProjectRoleTemplates of their namespace.
+	// +kubebuilder:validation:Enum=OrganizationRoleTemplate;ProjectRoleTemplate
+	// +kubebuilder:default=OrganizationRoleTemplate
+	Kind string `json:"kind,omitempty"`
+	// Name of the referenced role template.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
+// RoleTemplateCRDRules generates rules for all installed CustomResourceDefinitions matching the selector.
+type RoleTemplateCRDRules struct {
+	// Selector selects CustomResourceDefinitions by their labels, e.g. all CRDs labelled `bulward.io/project-scoped=true`.
+	Selector metav1.LabelSelector `json:"selector"`
+	// Access is the access tier that is granted on the resources of the selected CustomResourceDefinitions.
+	Access CRDAccessTier `json:"access"`
+}
+
+// CRDAccessTier is a predefined set of verbs that is granted on custom resources.
+// +kubebuilder:validation:Enum=View;Edit;Admin
+type CRDAccessTier string
+
+// Values of CRDAccessTier.
+const (
+	// CRDAccessView allows to read the custom resources.
+	CRDAccessView CRDAccessTier = "View"
+	// CRDAccessEdit additionally allows to create, change and delete the custom resources.
+	CRDAccessEdit CRDAccessTier = "Edit"
+	// CRDAccessAdmin additionally allows to delete collections of the custom resources.
+	CRDAccessAdmin CRDAccessTier = "Admin"
+)
+
+// Verbs returns the verbs granted by the access tier.
+func (t CRDAccessTier) Verbs() []string {
+	switch t {
+	case CRDAccessAdmin:
+		return []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+	case CRDAccessEdit:
+		return []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+	default:
+		return []string{"get", "list", "watch"}
+	}
+}
+
+// RoleTemplateTargetState describes the state of the rollout of a role template to a single target.
+// +kubebuilder:validation:Enum=Ready;Failed
+type RoleTemplateTargetState string
+
+// Values of RoleTemplateTargetState.
+const (
+	RoleTemplateTargetReady  RoleTemplateTargetState = "Ready"
+	RoleTemplateTargetFailed RoleTemplateTargetState = "Failed"
+)
+
+// MaxFailedTargets is the maximum number of failed targets recorded in the rollout status of role templates,
+// so the status stays small, even if the rollout fails for thousands of targets.
+const MaxFailedTargets = 10
+
+// RoleTemplateRollout summarizes the rollout of a role template to all of its targets.
+type RoleTemplateRollout struct {
+	// Targets is the number of Organizations and Projects selected by the role template.
+	Targets int `json:"targets"`
+	// Ready is the number of targets with an up-to-date Role and RoleBinding.
+	Ready int `json:"ready"`
+	// Failed is the number of targets the Role or RoleBinding could not be reconciled for.
+	Failed int `json:"failed"`
+	// Pending is the number of targets, that wait for a staged rollout to reach them.
+	Pending int `json:"pending,omitempty"`
+	// FailedTargets lists the first failed targets, at most MaxFailedTargets.
+	FailedTargets []RoleTemplateTarget `json:"failedTargets,omitempty"`
 }
+
+const (
+	// RoleRevocationAnnotation is set on user created Roles in Organization and Project namespaces,
+	// when they grant rules that are not granted by any role template in the namespace.
+	// It holds the JSON encoded rules and whether they were revoked or just reported in dry-run mode.
+	RoleRevocationAnnotation = "bulward.io/role-revocation"
+	// RoleTemplateRevisionAnnotation is set on Roles and RoleBindings of role templates
+	// and holds the revision of the template they were rolled out from.
+	RoleTemplateRevisionAnnotation = "bulward.io/role-template-revision"
+	// RoleTemplateUnmanagedAnnotation set to "true" on a default OrganizationRoleTemplate stops Bulward from updating or deleting it,
+	// so admins can edit their copy of the default.
+	RoleTemplateUnmanagedAnnotation = "bulward.io/unmanaged"
+	// RoleTemplateVersionAnnotation holds the version of a default OrganizationRoleTemplate.
+	// Managed defaults are updated, when their version differs from the one shipped with Bulward.
+	RoleTemplateVersionAnnotation = "bulward.io/role-template-version"
+	// LastAppliedRBACAnnotation is set on Roles and RoleBindings of role templates and holds the rules or subjects,
+	// that were last applied by Bulward. Changes by others are detected by comparing it with the current rules or subjects.
+	LastAppliedRBACAnnotation = "bulward.io/last-applied-rbac"
+)
+
+// DefaultRoleTemplateLabel is set on OrganizationRoleTemplates, that are managed by Bulward as part of the default set.
+const DefaultRoleTemplateLabel = "bulward.io/default-role-template"
+
+// ManagedLabel is set to "true" on the Namespaces, Roles and RoleBindings that Bulward manages.
+// Only the manager may change or delete them, tenants change them via the owning Organization, Project or role template.
+const ManagedLabel = "bulward.io/managed"
+
+// RoleRevocationModeLabel can be set on Organization and Project namespaces by cluster admins,
+// to override the role revocation mode of the manager flags for the namespace.
+const RoleRevocationModeLabel = "bulward.io/role-revocation-mode"
+
+// RoleRevocationMode controls if user created Roles are trimmed to the rules granted by the role templates.
+type RoleRevocationMode string
+
+// Values of the RoleRevocationModeLabel.
+const (
+	// RoleRevocationEnforce revokes rules that are not granted by any role template.
+	RoleRevocationEnforce RoleRevocationMode = "enforce"
+	// RoleRevocationDryRun only reports rules that would be revoked.
+	RoleRevocationDryRun RoleRevocationMode = "dry-run"
+	// RoleRevocationDisabled leaves user created Roles alone.
+	RoleRevocationDisabled RoleRevocationMode = "disabled"
+)
+
+const (
+	// OrganizationRoleTemplateRolePrefix prefixes the default name of Roles and RoleBindings of OrganizationRoleTemplates.
+	OrganizationRoleTemplateRolePrefix = "organizationroletemplate:"
+	// ProjectRoleTemplateRolePrefix prefixes the default name of Roles and RoleBindings of ProjectRoleTemplates.
+	ProjectRoleTemplateRolePrefix = "projectroletemplate:"
+)
@@ -131,6 +131,27 @@ func (in *OrganizationRoleTemplateMetadata) DeepCopy() *OrganizationRoleTemplate
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleTemplateRolloutStrategy) DeepCopyInto(out *OrganizationRoleTemplateRolloutStrategy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.BatchInterval = in.BatchInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleTemplateRolloutStrategy.
+func (in *OrganizationRoleTemplateRolloutStrategy) DeepCopy() *OrganizationRoleTemplateRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleTemplateRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrganizationRoleTemplateSpec) DeepCopyInto(out *OrganizationRoleTemplateSpec) {
 	*out = *in
@@ -149,6 +170,16 @@ func (in *OrganizationRoleTemplateSpec) DeepCopyInto(out *OrganizationRoleTempla
 		*out = make([]BindingType, len(*in))
 		copy(*out, *in)
 	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProjectSelector != nil {
+		in, out := &in.ProjectSelector, &out.ProjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Rules != nil {
 		in, out := &in.Rules, &out.Rules
 		*out = make([]v1.PolicyRule, len(*in))
@@ -156,6 +187,28 @@ func (in *OrganizationRoleTemplateSpec) DeepCopyInto(out *OrganizationRoleTempla
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClusterRoleRef != nil {
+		in, out := &in.ClusterRoleRef, &out.ClusterRoleRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.CRDRules != nil {
+		in, out := &in.CRDRules, &out.CRDRules
+		*out = make([]RoleTemplateCRDRules, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]RoleTemplateReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(OrganizationRoleTemplateRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleTemplateSpec.
@@ -168,6 +221,30 @@ func (in *OrganizationRoleTemplateSpec) DeepCopy() *OrganizationRoleTemplateSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationRoleTemplateStagedRollout) DeepCopyInto(out *OrganizationRoleTemplateStagedRollout) {
+	*out = *in
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailedOrganizations != nil {
+		in, out := &in.FailedOrganizations, &out.FailedOrganizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationRoleTemplateStagedRollout.
+func (in *OrganizationRoleTemplateStagedRollout) DeepCopy() *OrganizationRoleTemplateStagedRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationRoleTemplateStagedRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrganizationRoleTemplateStatus) DeepCopyInto(out *OrganizationRoleTemplateStatus) {
 	*out = *in
@@ -178,10 +255,11 @@ func (in *OrganizationRoleTemplateStatus) DeepCopyInto(out *OrganizationRoleTemp
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]RoleTemplateTarget, len(*in))
-		copy(*out, *in)
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.StagedRollout != nil {
+		in, out := &in.StagedRollout, &out.StagedRollout
+		*out = new(OrganizationRoleTemplateStagedRollout)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -310,6 +388,23 @@ func (in *ProjectRoleTemplateSpec) DeepCopyInto(out *ProjectRoleTemplateSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ClusterRoleRef != nil {
+		in, out := &in.ClusterRoleRef, &out.ClusterRoleRef
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.CRDRules != nil {
+		in, out := &in.CRDRules, &out.CRDRules
+		*out = make([]RoleTemplateCRDRules, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]RoleTemplateReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleTemplateSpec.
@@ -332,11 +427,7 @@ func (in *ProjectRoleTemplateStatus) DeepCopyInto(out *ProjectRoleTemplateStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Targets != nil {
-		in, out := &in.Targets, &out.Targets
-		*out = make([]RoleTemplateTarget, len(*in))
-		copy(*out, *in)
-	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleTemplateStatus.
@@ -349,9 +440,123 @@ func (in *ProjectRoleTemplateStatus) DeepCopy() *ProjectRoleTemplateStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACChange) DeepCopyInto(out *RBACChange) {
+	*out = *in
+	if in.Current != nil {
+		in, out := &in.Current, &out.Current
+		*out = new(RBACObjectState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Desired != nil {
+		in, out := &in.Desired, &out.Desired
+		*out = new(RBACObjectState)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACChange.
+func (in *RBACChange) DeepCopy() *RBACChange {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RBACObjectState) DeepCopyInto(out *RBACObjectState) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.RoleRef)
+		**out = **in
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACObjectState.
+func (in *RBACObjectState) DeepCopy() *RBACObjectState {
+	if in == nil {
+		return nil
+	}
+	out := new(RBACObjectState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateCRDRules) DeepCopyInto(out *RoleTemplateCRDRules) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateCRDRules.
+func (in *RoleTemplateCRDRules) DeepCopy() *RoleTemplateCRDRules {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateCRDRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateReference) DeepCopyInto(out *RoleTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateReference.
+func (in *RoleTemplateReference) DeepCopy() *RoleTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleTemplateRollout) DeepCopyInto(out *RoleTemplateRollout) {
+	*out = *in
+	if in.FailedTargets != nil {
+		in, out := &in.FailedTargets, &out.FailedTargets
+		*out = make([]RoleTemplateTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateRollout.
+func (in *RoleTemplateRollout) DeepCopy() *RoleTemplateRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleTemplateRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleTemplateTarget) DeepCopyInto(out *RoleTemplateTarget) {
 	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleTemplateTarget.
@@ -363,3 +568,61 @@ func (in *RoleTemplateTarget) DeepCopy() *RoleTemplateTarget {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatePreviewSpec) DeepCopyInto(out *TemplatePreviewSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatePreviewSpec.
+func (in *TemplatePreviewSpec) DeepCopy() *TemplatePreviewSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatePreviewSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatePreviewStatus) DeepCopyInto(out *TemplatePreviewStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]TemplatePreviewTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]RBACChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatePreviewStatus.
+func (in *TemplatePreviewStatus) DeepCopy() *TemplatePreviewStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatePreviewStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatePreviewTarget) DeepCopyInto(out *TemplatePreviewTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatePreviewTarget.
+func (in *TemplatePreviewTarget) DeepCopy() *TemplatePreviewTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatePreviewTarget)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,7 +24,7 @@ import (
 	io "io"
 
 	proto "github.com/gogo/protobuf/proto"
-	v11 "k8s.io/api/rbac/v1"
+	v1 "k8s.io/api/rbac/v1"
 
 	math "math"
 	math_bits "math/bits"
@@ -43,10 +43,66 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+func (m *MemberProvenance) Reset()      { *m = MemberProvenance{} }
+func (*MemberProvenance) ProtoMessage() {}
+func (*MemberProvenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13845ae8af47564e, []int{0}
+}
+func (m *MemberProvenance) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberProvenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *MemberProvenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberProvenance.Merge(m, src)
+}
+func (m *MemberProvenance) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberProvenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberProvenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberProvenance proto.InternalMessageInfo
+
+func (m *MemberSource) Reset()      { *m = MemberSource{} }
+func (*MemberSource) ProtoMessage() {}
+func (*MemberSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_13845ae8af47564e, []int{1}
+}
+func (m *MemberSource) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *MemberSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberSource.Merge(m, src)
+}
+func (m *MemberSource) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberSource proto.InternalMessageInfo
+
 func (m *ObjectReference) Reset()      { *m = ObjectReference{} }
 func (*ObjectReference) ProtoMessage() {}
 func (*ObjectReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{0}
+	return fileDescriptor_13845ae8af47564e, []int{2}
 }
 func (m *ObjectReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -74,7 +130,7 @@ var xxx_messageInfo_ObjectReference proto.InternalMessageInfo
 func (m *Organization) Reset()      { *m = Organization{} }
 func (*Organization) ProtoMessage() {}
 func (*Organization) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{1}
+	return fileDescriptor_13845ae8af47564e, []int{3}
 }
 func (m *Organization) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -102,7 +158,7 @@ var xxx_messageInfo_Organization proto.InternalMessageInfo
 func (m *OrganizationCondition) Reset()      { *m = OrganizationCondition{} }
 func (*OrganizationCondition) ProtoMessage() {}
 func (*OrganizationCondition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{2}
+	return fileDescriptor_13845ae8af47564e, []int{4}
 }
 func (m *OrganizationCondition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -130,7 +186,7 @@ var xxx_messageInfo_OrganizationCondition proto.InternalMessageInfo
 func (m *OrganizationList) Reset()      { *m = OrganizationList{} }
 func (*OrganizationList) ProtoMessage() {}
 func (*OrganizationList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{3}
+	return fileDescriptor_13845ae8af47564e, []int{5}
 }
 func (m *OrganizationList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -158,7 +214,7 @@ var xxx_messageInfo_OrganizationList proto.InternalMessageInfo
 func (m *OrganizationMetadata) Reset()      { *m = OrganizationMetadata{} }
 func (*OrganizationMetadata) ProtoMessage() {}
 func (*OrganizationMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{4}
+	return fileDescriptor_13845ae8af47564e, []int{6}
 }
 func (m *OrganizationMetadata) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -186,7 +242,7 @@ var xxx_messageInfo_OrganizationMetadata proto.InternalMessageInfo
 func (m *OrganizationSpec) Reset()      { *m = OrganizationSpec{} }
 func (*OrganizationSpec) ProtoMessage() {}
 func (*OrganizationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{5}
+	return fileDescriptor_13845ae8af47564e, []int{7}
 }
 func (m *OrganizationSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -214,7 +270,7 @@ var xxx_messageInfo_OrganizationSpec proto.InternalMessageInfo
 func (m *OrganizationStatus) Reset()      { *m = OrganizationStatus{} }
 func (*OrganizationStatus) ProtoMessage() {}
 func (*OrganizationStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{6}
+	return fileDescriptor_13845ae8af47564e, []int{8}
 }
 func (m *OrganizationStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -242,7 +298,7 @@ var xxx_messageInfo_OrganizationStatus proto.InternalMessageInfo
 func (m *Project) Reset()      { *m = Project{} }
 func (*Project) ProtoMessage() {}
 func (*Project) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{7}
+	return fileDescriptor_13845ae8af47564e, []int{9}
 }
 func (m *Project) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -270,7 +326,7 @@ var xxx_messageInfo_Project proto.InternalMessageInfo
 func (m *ProjectCondition) Reset()      { *m = ProjectCondition{} }
 func (*ProjectCondition) ProtoMessage() {}
 func (*ProjectCondition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{8}
+	return fileDescriptor_13845ae8af47564e, []int{10}
 }
 func (m *ProjectCondition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -298,7 +354,7 @@ var xxx_messageInfo_ProjectCondition proto.InternalMessageInfo
 func (m *ProjectList) Reset()      { *m = ProjectList{} }
 func (*ProjectList) ProtoMessage() {}
 func (*ProjectList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{9}
+	return fileDescriptor_13845ae8af47564e, []int{11}
 }
 func (m *ProjectList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -326,7 +382,7 @@ var xxx_messageInfo_ProjectList proto.InternalMessageInfo
 func (m *ProjectSpec) Reset()      { *m = ProjectSpec{} }
 func (*ProjectSpec) ProtoMessage() {}
 func (*ProjectSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{10}
+	return fileDescriptor_13845ae8af47564e, []int{12}
 }
 func (m *ProjectSpec) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -354,7 +410,7 @@ var xxx_messageInfo_ProjectSpec proto.InternalMessageInfo
 func (m *ProjectStatus) Reset()      { *m = ProjectStatus{} }
 func (*ProjectStatus) ProtoMessage() {}
 func (*ProjectStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_13845ae8af47564e, []int{11}
+	return fileDescriptor_13845ae8af47564e, []int{13}
 }
 func (m *ProjectStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -380,6 +436,8 @@ func (m *ProjectStatus) XXX_DiscardUnknown() {
 var xxx_messageInfo_ProjectStatus proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterType((*MemberProvenance)(nil), "k8c.io.bulward.pkg.apis.storage.v1alpha1.MemberProvenance")
+	proto.RegisterType((*MemberSource)(nil), "k8c.io.bulward.pkg.apis.storage.v1alpha1.MemberSource")
 	proto.RegisterType((*ObjectReference)(nil), "k8c.io.bulward.pkg.apis.storage.v1alpha1.ObjectReference")
 	proto.RegisterType((*Organization)(nil), "k8c.io.bulward.pkg.apis.storage.v1alpha1.Organization")
 	proto.RegisterType((*OrganizationCondition)(nil), "k8c.io.bulward.pkg.apis.storage.v1alpha1.OrganizationCondition")
@@ -399,67 +457,157 @@ func init() {
 }
 
 var fileDescriptor_13845ae8af47564e = []byte{
-	// 953 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x55, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0xf6, 0xfa, 0x27, 0x3f, 0xe3, 0x84, 0x44, 0x43, 0x91, 0x4c, 0x90, 0xd6, 0xed, 0x0a, 0x55,
-	0x29, 0x12, 0xb3, 0xa4, 0xd0, 0x36, 0xad, 0x0a, 0x48, 0x5b, 0x04, 0x02, 0x35, 0x49, 0x35, 0xad,
-	0x40, 0x82, 0x4a, 0x30, 0x5e, 0x4f, 0xd6, 0x4b, 0xbc, 0x3f, 0xda, 0x19, 0xbb, 0x32, 0x57, 0x5c,
-	0xf0, 0x00, 0xbc, 0x04, 0x6f, 0xd0, 0x17, 0x40, 0xbd, 0xc9, 0x0d, 0x52, 0xc5, 0x55, 0x2f, 0x90,
-	0x45, 0xcc, 0x5b, 0xe4, 0x0a, 0xcd, 0xec, 0x8c, 0x77, 0xd7, 0x76, 0xa8, 0x6d, 0x89, 0x4a, 0x48,
-	0xdc, 0xed, 0xce, 0x9c, 0xef, 0x3b, 0x3f, 0x73, 0xce, 0x77, 0xc0, 0xfe, 0xc9, 0xbe, 0x8b, 0xfc,
-	0xc8, 0x6e, 0xf5, 0xba, 0x4f, 0x48, 0xd2, 0xb6, 0xe3, 0x13, 0xcf, 0x26, 0xb1, 0xcf, 0x6c, 0xc6,
-	0xa3, 0x84, 0x78, 0xd4, 0xee, 0xef, 0x91, 0x6e, 0xdc, 0x21, 0x7b, 0xb6, 0x47, 0x43, 0x9a, 0x10,
-	0x4e, 0xdb, 0x28, 0x4e, 0x22, 0x1e, 0xc1, 0xdd, 0x14, 0x89, 0x14, 0x12, 0xc5, 0x27, 0x1e, 0x12,
-	0x48, 0xa4, 0x90, 0x48, 0x23, 0x77, 0xde, 0xf5, 0x7c, 0xde, 0xe9, 0xb5, 0x90, 0x1b, 0x05, 0xb6,
-	0x17, 0x79, 0x91, 0x2d, 0x09, 0x5a, 0xbd, 0x63, 0xf9, 0x27, 0x7f, 0xe4, 0x57, 0x4a, 0xbc, 0x63,
-	0x9d, 0xec, 0x33, 0x11, 0x12, 0x89, 0x7d, 0x3b, 0x69, 0x11, 0xd7, 0xee, 0x4f, 0x39, 0xdf, 0xf9,
-	0x20, 0xb3, 0x09, 0x88, 0xdb, 0xf1, 0x43, 0x9a, 0x0c, 0xb2, 0xd8, 0x03, 0xca, 0xc9, 0x2c, 0x94,
-	0x7d, 0x11, 0x2a, 0xe9, 0x85, 0xdc, 0x0f, 0xe8, 0x14, 0xe0, 0xe6, 0xcb, 0x00, 0xcc, 0xed, 0xd0,
-	0x80, 0x4c, 0xe2, 0xac, 0xf7, 0xc1, 0xd6, 0x51, 0xeb, 0x7b, 0xea, 0x72, 0x4c, 0x8f, 0x69, 0x42,
-	0x43, 0x97, 0xc2, 0xcb, 0xa0, 0x1a, 0x92, 0x80, 0x36, 0x8c, 0xcb, 0xc6, 0xee, 0xba, 0xb3, 0x71,
-	0x3a, 0x6c, 0x96, 0x46, 0xc3, 0x66, 0xf5, 0x90, 0x04, 0x14, 0xcb, 0x1b, 0xeb, 0x69, 0x19, 0x6c,
-	0x1c, 0x25, 0x1e, 0x09, 0xfd, 0x1f, 0x08, 0xf7, 0xa3, 0x10, 0x7e, 0x07, 0xd6, 0x44, 0x26, 0x6d,
-	0xc2, 0x89, 0x84, 0xd5, 0xaf, 0xbf, 0x87, 0xd2, 0x80, 0x50, 0x3e, 0xa0, 0xac, 0xf2, 0xc2, 0x1a,
-	0xf5, 0xf7, 0x50, 0xea, 0xfb, 0x80, 0x72, 0xe2, 0x40, 0xe5, 0x08, 0x64, 0x67, 0x78, 0xcc, 0x0a,
-	0x1f, 0x83, 0x2a, 0x8b, 0xa9, 0xdb, 0x28, 0x4b, 0xf6, 0x3b, 0x68, 0xde, 0x27, 0x45, 0xf9, 0x38,
-	0x1f, 0xc6, 0xd4, 0xcd, 0x12, 0x12, 0x7f, 0x58, 0xb2, 0xc2, 0x36, 0x58, 0x61, 0x9c, 0xf0, 0x1e,
-	0x6b, 0x54, 0x24, 0xff, 0xdd, 0x25, 0xf9, 0x25, 0x87, 0xf3, 0x9a, 0xf2, 0xb0, 0x92, 0xfe, 0x63,
-	0xc5, 0x6d, 0xfd, 0x51, 0x06, 0x6f, 0xe4, 0xcd, 0xef, 0x45, 0x61, 0xdb, 0x97, 0xf5, 0xfb, 0x10,
-	0x54, 0xf9, 0x20, 0xd6, 0x25, 0xbf, 0xa6, 0x23, 0x7c, 0x34, 0x88, 0xe9, 0xf9, 0xb0, 0xf9, 0xe6,
-	0x4c, 0x90, 0xb8, 0xc4, 0x12, 0x06, 0x6f, 0x8f, 0xc3, 0x2f, 0x4b, 0x82, 0x2b, 0xc5, 0x00, 0xce,
-	0x87, 0xcd, 0xad, 0x31, 0xac, 0x18, 0x13, 0xec, 0x03, 0xd8, 0x25, 0x8c, 0x3f, 0x4a, 0x48, 0xc8,
-	0x52, 0x5a, 0x3f, 0xa0, 0xaa, 0x0a, 0xef, 0xcc, 0xf7, 0x86, 0x02, 0xe1, 0xec, 0x28, 0x97, 0xf0,
-	0xfe, 0x14, 0x1b, 0x9e, 0xe1, 0x01, 0x5e, 0x05, 0x2b, 0x09, 0x25, 0x2c, 0x0a, 0x1b, 0x55, 0x19,
-	0xf2, 0xb8, 0x66, 0x58, 0x9e, 0x62, 0x75, 0x0b, 0xaf, 0x81, 0xd5, 0x80, 0x32, 0x46, 0x3c, 0xda,
-	0xa8, 0x49, 0xc3, 0x2d, 0x65, 0xb8, 0x7a, 0x90, 0x1e, 0x63, 0x7d, 0x6f, 0xfd, 0x66, 0x80, 0xed,
-	0x7c, 0xa5, 0xee, 0xfb, 0x8c, 0xc3, 0xc7, 0x53, 0x9d, 0x89, 0xe6, 0xcb, 0x4a, 0xa0, 0x65, 0x5f,
-	0x6e, 0x2b, 0x87, 0x6b, 0xfa, 0x24, 0xd7, 0x95, 0xdf, 0x80, 0x9a, 0xcf, 0x69, 0x20, 0xea, 0x5e,
-	0xd9, 0xad, 0x5f, 0xbf, 0xb9, 0x5c, 0xdb, 0x38, 0x9b, 0xca, 0x45, 0xed, 0x73, 0x41, 0x86, 0x53,
-	0x4e, 0xeb, 0x27, 0x03, 0x5c, 0xca, 0x9b, 0x1d, 0x68, 0xaf, 0x37, 0x40, 0xbd, 0xed, 0xb3, 0xb8,
-	0x4b, 0x06, 0x87, 0xd9, 0x9c, 0xbe, 0xae, 0x38, 0xea, 0x9f, 0x64, 0x57, 0x38, 0x6f, 0x27, 0x61,
-	0x94, 0xb9, 0x89, 0x1f, 0x0b, 0x36, 0xd5, 0x2a, 0x19, 0x2c, 0xbb, 0xc2, 0x79, 0x3b, 0xeb, 0xd7,
-	0x89, 0xb2, 0x8a, 0xb1, 0x81, 0x9d, 0xa9, 0xb2, 0x7e, 0xb4, 0x5c, 0xee, 0x3a, 0x29, 0x67, 0x43,
-	0x94, 0x58, 0xff, 0xe5, 0x4a, 0x7c, 0x0f, 0xac, 0x44, 0x4f, 0x42, 0x9a, 0xe8, 0x1a, 0xbf, 0x95,
-	0x7b, 0x3e, 0x24, 0x44, 0x57, 0x3c, 0xd6, 0xc3, 0x9e, 0xd4, 0x8c, 0xac, 0x8b, 0x8e, 0x24, 0x04,
-	0x2b, 0xa8, 0xf5, 0xac, 0x02, 0xe0, 0xf4, 0xa0, 0xc2, 0x63, 0xb0, 0x2e, 0xf4, 0x8c, 0xc5, 0xc4,
-	0xa5, 0x2a, 0x8d, 0xdb, 0x0b, 0xa4, 0x51, 0xd4, 0x4d, 0x67, 0x73, 0x34, 0x6c, 0xae, 0x1f, 0x6a,
-	0x3e, 0x9c, 0x51, 0xc3, 0x2f, 0x00, 0x8c, 0x5a, 0x8c, 0x26, 0x7d, 0xda, 0xfe, 0x2c, 0xd5, 0x5f,
-	0xfd, 0x00, 0x95, 0x6c, 0x70, 0x8e, 0xa6, 0x2c, 0xf0, 0x0c, 0x14, 0x64, 0x00, 0xb8, 0x7a, 0x96,
-	0x85, 0x5c, 0x89, 0x9a, 0x7c, 0xbc, 0x5c, 0xed, 0xc7, 0x9a, 0x90, 0x69, 0xef, 0xf8, 0x88, 0xe1,
-	0x9c, 0x1b, 0x78, 0x17, 0xd4, 0xe2, 0x0e, 0x61, 0x54, 0x0d, 0xeb, 0x55, 0xdd, 0xaf, 0x0f, 0xc4,
-	0xe1, 0xf9, 0xb0, 0x59, 0x90, 0x35, 0x79, 0x28, 0xd5, 0x29, 0x05, 0xc1, 0x4f, 0xc5, 0x0c, 0x07,
-	0x2d, 0xf1, 0x86, 0xb5, 0x97, 0xbf, 0x61, 0x6e, 0xc0, 0x25, 0x06, 0x6b, 0xb0, 0xf5, 0x4b, 0x19,
-	0xac, 0x3e, 0x48, 0x22, 0x61, 0xf5, 0x0a, 0x36, 0xce, 0x57, 0x85, 0x8d, 0x73, 0x63, 0xfe, 0x12,
-	0xab, 0x10, 0x2f, 0x5c, 0x36, 0xdf, 0x4e, 0x2c, 0x9b, 0x5b, 0x8b, 0x53, 0xff, 0xf3, 0x9e, 0xf9,
-	0xbd, 0x0c, 0xb6, 0x95, 0x65, 0xb6, 0x62, 0xf6, 0x0b, 0x2b, 0xe6, 0xed, 0x89, 0x15, 0x73, 0x69,
-	0xd2, 0xfe, 0xff, 0xed, 0x32, 0xb1, 0x5d, 0x9e, 0x19, 0xa0, 0xae, 0x8a, 0xf4, 0x0a, 0x16, 0xcb,
-	0x97, 0xc5, 0xc5, 0xb2, 0xb7, 0x70, 0x8b, 0x5c, 0xb0, 0x53, 0xf0, 0x38, 0x09, 0x29, 0xe3, 0x99,
-	0xb8, 0x1a, 0xcb, 0x8b, 0xeb, 0xd3, 0x0a, 0xd8, 0x2c, 0x34, 0xe6, 0x7f, 0x52, 0x57, 0xc3, 0x19,
-	0xba, 0x7a, 0x67, 0xe1, 0xb2, 0xcf, 0x2f, 0xa9, 0xb7, 0x8a, 0x92, 0x7a, 0x65, 0x52, 0x52, 0xf5,
-	0x04, 0xff, 0x5b, 0x6a, 0xea, 0xa0, 0xd3, 0x33, 0xb3, 0xf4, 0xfc, 0xcc, 0x2c, 0xbd, 0x38, 0x33,
-	0x4b, 0x3f, 0x8e, 0x4c, 0xe3, 0x74, 0x64, 0x1a, 0xcf, 0x47, 0xa6, 0xf1, 0x62, 0x64, 0x1a, 0x7f,
-	0x8e, 0x4c, 0xe3, 0xe7, 0xbf, 0xcc, 0xd2, 0xd7, 0x6b, 0x3a, 0xc3, 0xbf, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0xe2, 0xac, 0x9e, 0x0d, 0x80, 0x0d, 0x00, 0x00,
+	// 1111 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0xcb, 0x6e, 0x23, 0x45,
+	0x14, 0x4d, 0x3b, 0x7e, 0x24, 0xe5, 0x84, 0x58, 0xc5, 0x20, 0x35, 0x41, 0xb2, 0x9d, 0x16, 0x1a,
+	0x65, 0x90, 0xe8, 0x26, 0x61, 0x1e, 0x99, 0xd1, 0x00, 0x92, 0x87, 0x87, 0x80, 0xc9, 0x43, 0x95,
+	0x08, 0x24, 0x18, 0x09, 0xca, 0xdd, 0x15, 0xbb, 0xb1, 0xfb, 0xa1, 0xae, 0xb6, 0x87, 0xb0, 0x9a,
+	0x05, 0x1f, 0xc0, 0x4f, 0xf0, 0x03, 0x08, 0x89, 0x15, 0x2b, 0x24, 0x94, 0x0d, 0xd2, 0x88, 0xd5,
+	0x2c, 0x90, 0x45, 0xcc, 0x3f, 0xb0, 0xc8, 0x0a, 0x55, 0x75, 0x55, 0x3f, 0x6c, 0x67, 0x62, 0x1b,
+	0x81, 0x04, 0x9a, 0x5d, 0x77, 0xd5, 0x3d, 0xe7, 0xde, 0x7b, 0xba, 0xea, 0x9e, 0x06, 0x3b, 0x9d,
+	0x1d, 0x53, 0xb7, 0x3d, 0xa3, 0xd9, 0xeb, 0x3e, 0xc4, 0x81, 0x65, 0xf8, 0x9d, 0x96, 0x81, 0x7d,
+	0x9b, 0x1a, 0x34, 0xf4, 0x02, 0xdc, 0x22, 0x46, 0x7f, 0x0b, 0x77, 0xfd, 0x36, 0xde, 0x32, 0x5a,
+	0xc4, 0x25, 0x01, 0x0e, 0x89, 0xa5, 0xfb, 0x81, 0x17, 0x7a, 0x70, 0x33, 0x42, 0xea, 0x02, 0xa9,
+	0xfb, 0x9d, 0x96, 0xce, 0x90, 0xba, 0x40, 0xea, 0x12, 0xb9, 0xfe, 0x6a, 0xcb, 0x0e, 0xdb, 0xbd,
+	0xa6, 0x6e, 0x7a, 0x8e, 0xd1, 0xf2, 0x5a, 0x9e, 0xc1, 0x09, 0x9a, 0xbd, 0x63, 0xfe, 0xc6, 0x5f,
+	0xf8, 0x53, 0x44, 0xbc, 0xae, 0x75, 0x76, 0x28, 0x2b, 0x09, 0xfb, 0xb6, 0x11, 0x34, 0xb1, 0x69,
+	0xf4, 0xc7, 0x92, 0xaf, 0x5f, 0x4f, 0x62, 0x1c, 0x6c, 0xb6, 0x6d, 0x97, 0x04, 0x27, 0x49, 0xed,
+	0x0e, 0x09, 0xf1, 0x24, 0x94, 0x71, 0x11, 0x2a, 0xe8, 0xb9, 0xa1, 0xed, 0x90, 0x31, 0xc0, 0xcd,
+	0xcb, 0x00, 0xd4, 0x6c, 0x13, 0x07, 0x8f, 0xe2, 0xb4, 0x1f, 0x15, 0x50, 0xd9, 0x25, 0x4e, 0x93,
+	0x04, 0x07, 0x81, 0xd7, 0x27, 0x2e, 0x76, 0x4d, 0x02, 0xdf, 0x05, 0x25, 0xda, 0x6b, 0x7e, 0x41,
+	0xcc, 0x50, 0x55, 0xea, 0xca, 0x66, 0x79, 0xfb, 0x25, 0x3d, 0xa2, 0x67, 0xb2, 0xe9, 0xac, 0x53,
+	0xbd, 0xbf, 0xa5, 0x1f, 0x46, 0x21, 0x8d, 0xb5, 0xd3, 0x41, 0x6d, 0x61, 0x38, 0xa8, 0x95, 0xc4,
+	0x02, 0x92, 0x60, 0x88, 0x41, 0x89, 0x7a, 0xbd, 0xc0, 0x24, 0x54, 0xcd, 0xd5, 0x17, 0x37, 0xcb,
+	0xdb, 0x37, 0xf5, 0x69, 0x3f, 0x85, 0x1e, 0x15, 0x75, 0xc8, 0xe1, 0xa9, 0x14, 0x11, 0x1d, 0x92,
+	0xbc, 0xda, 0x31, 0x58, 0x49, 0x47, 0xc2, 0xeb, 0x20, 0xdf, 0xb1, 0x5d, 0x8b, 0xd7, 0xbd, 0xdc,
+	0xa8, 0x0b, 0x5c, 0xfe, 0x43, 0xdb, 0xb5, 0xce, 0x07, 0xb5, 0x4a, 0x3a, 0x96, 0xad, 0x21, 0x1e,
+	0x0d, 0xeb, 0x20, 0xef, 0x62, 0x87, 0xa8, 0x39, 0x8e, 0x5a, 0x91, 0xa8, 0x3d, 0xec, 0x10, 0xc4,
+	0x77, 0xb4, 0xd7, 0xc1, 0xda, 0x7e, 0xd4, 0x1d, 0x39, 0x26, 0x01, 0x61, 0x2a, 0x49, 0x90, 0x72,
+	0x21, 0xe8, 0xfb, 0x1c, 0x58, 0xd9, 0x0f, 0x5a, 0xd8, 0xb5, 0xbf, 0xc2, 0xa1, 0xed, 0xb9, 0xf0,
+	0x73, 0xb0, 0xc4, 0xbe, 0xb8, 0x85, 0x43, 0x2c, 0x94, 0x7d, 0x2d, 0xa5, 0x6c, 0xfc, 0xe1, 0x12,
+	0x59, 0x58, 0x34, 0xd3, 0x3a, 0xca, 0xbd, 0x4b, 0x42, 0xdc, 0x80, 0x22, 0x11, 0x48, 0xd6, 0x50,
+	0xcc, 0x0a, 0x1f, 0x80, 0x3c, 0xf5, 0x89, 0xc9, 0x3b, 0x29, 0x6f, 0xdf, 0x99, 0x5e, 0xef, 0x74,
+	0x9d, 0x87, 0x3e, 0x31, 0x93, 0x86, 0xd8, 0x1b, 0xe2, 0xac, 0xd0, 0x02, 0x45, 0x1a, 0xe2, 0xb0,
+	0x47, 0xd5, 0x45, 0xce, 0x7f, 0x77, 0x4e, 0x7e, 0xce, 0xd1, 0x78, 0x4e, 0x64, 0x28, 0x46, 0xef,
+	0x48, 0x70, 0x6b, 0xbf, 0xe5, 0xc0, 0x0b, 0xe9, 0xf0, 0x7b, 0x9e, 0x6b, 0xd9, 0x5c, 0xbf, 0x37,
+	0x40, 0x3e, 0x3c, 0xf1, 0xa5, 0xe4, 0xd7, 0x64, 0x85, 0x47, 0x27, 0x3e, 0x39, 0x1f, 0xd4, 0x5e,
+	0x9c, 0x08, 0x62, 0x9b, 0x88, 0xc3, 0xe0, 0xed, 0xb8, 0xfc, 0xe8, 0x43, 0x6f, 0x64, 0x0b, 0x38,
+	0x1f, 0xd4, 0xd6, 0x62, 0x58, 0xb6, 0x26, 0xd8, 0x07, 0xb0, 0x8b, 0x69, 0x78, 0x14, 0x60, 0x97,
+	0x46, 0xb4, 0xb6, 0x43, 0x84, 0x0a, 0xaf, 0x4c, 0xf7, 0x0d, 0x19, 0xa2, 0xb1, 0x2e, 0x52, 0xc2,
+	0xfb, 0x63, 0x6c, 0x68, 0x42, 0x06, 0x78, 0x15, 0x14, 0x03, 0x82, 0xa9, 0xe7, 0xaa, 0x79, 0x5e,
+	0x72, 0xac, 0x19, 0xe2, 0xab, 0x48, 0xec, 0xc2, 0x6b, 0xa0, 0xe4, 0x10, 0x4a, 0x71, 0x8b, 0xa8,
+	0x05, 0x1e, 0x18, 0x5f, 0x99, 0xdd, 0x68, 0x19, 0xc9, 0x7d, 0xed, 0x17, 0x05, 0x54, 0xd2, 0x4a,
+	0xdd, 0xb7, 0x69, 0x08, 0x1f, 0x8c, 0x9d, 0x4c, 0x7d, 0xba, 0xae, 0x18, 0x9a, 0x9f, 0xcb, 0x8a,
+	0x48, 0xb8, 0x24, 0x57, 0x52, 0xa7, 0xf2, 0x53, 0x50, 0xb0, 0x43, 0xe2, 0xcc, 0x31, 0x06, 0xd2,
+	0x85, 0x36, 0x56, 0x45, 0x8a, 0xc2, 0xfb, 0x8c, 0x0c, 0x45, 0x9c, 0xda, 0xd7, 0x0a, 0xb8, 0x92,
+	0x0e, 0xdb, 0x95, 0x59, 0x6f, 0x80, 0xb2, 0x65, 0x53, 0xbf, 0x8b, 0x4f, 0xf6, 0x92, 0x7b, 0xfa,
+	0xbc, 0xe0, 0x28, 0xbf, 0x9d, 0x6c, 0xa1, 0x74, 0x1c, 0x87, 0x11, 0x6a, 0x06, 0xb6, 0xcf, 0xd8,
+	0xd4, 0xdc, 0x08, 0x2c, 0xd9, 0x42, 0xe9, 0x38, 0xed, 0xcf, 0x5c, 0x56, 0x56, 0x76, 0x6d, 0x60,
+	0x7b, 0x4c, 0xd6, 0x37, 0xe7, 0xeb, 0x5d, 0x36, 0xd5, 0x58, 0x61, 0x12, 0xcb, 0xb7, 0x94, 0xc4,
+	0xf7, 0x40, 0xd1, 0x7b, 0xe8, 0x92, 0x40, 0x6a, 0xfc, 0xd4, 0x91, 0x1d, 0x9f, 0xa2, 0x7d, 0x0e,
+	0x41, 0x02, 0x0a, 0xbf, 0x53, 0xc0, 0x06, 0xf9, 0xd2, 0xec, 0xf6, 0x2c, 0x62, 0xa5, 0xb3, 0x23,
+	0xaf, 0x4b, 0x8e, 0x88, 0xe3, 0x77, 0x71, 0x48, 0xd8, 0xdd, 0x67, 0x09, 0x6e, 0xcf, 0xd0, 0x48,
+	0x76, 0x72, 0xc6, 0x17, 0x77, 0xe3, 0x9d, 0xcb, 0x72, 0xa1, 0xcb, 0xcb, 0xd1, 0x7e, 0xce, 0x03,
+	0x38, 0x3e, 0x5d, 0xe0, 0x31, 0x58, 0x66, 0x43, 0x98, 0xfa, 0xd8, 0x24, 0x42, 0xfb, 0xbf, 0x51,
+	0xf2, 0xea, 0x70, 0x50, 0x5b, 0xde, 0x93, 0x7c, 0x28, 0xa1, 0x86, 0x1f, 0x00, 0xe8, 0x35, 0x29,
+	0x09, 0xfa, 0xc4, 0x7a, 0x2f, 0x32, 0x57, 0x79, 0x6a, 0x16, 0x93, 0xdb, 0xbe, 0x3f, 0x16, 0x81,
+	0x26, 0xa0, 0x20, 0x05, 0xc0, 0x94, 0x03, 0x48, 0xea, 0xfc, 0xd6, 0x7c, 0x07, 0x26, 0x1e, 0x64,
+	0x89, 0x61, 0xc4, 0x4b, 0x14, 0xa5, 0xd2, 0xc0, 0xbb, 0xa0, 0xe0, 0xb7, 0x31, 0x25, 0x62, 0xc2,
+	0x5c, 0x95, 0x97, 0xec, 0x80, 0x2d, 0x9e, 0x0f, 0x6a, 0x99, 0x59, 0xcc, 0x17, 0xf9, 0x48, 0x8d,
+	0x40, 0xec, 0x5f, 0xc1, 0xe1, 0xa6, 0x4a, 0xd5, 0xc2, 0xe5, 0x07, 0x2f, 0x35, 0x95, 0x38, 0x06,
+	0x49, 0x30, 0x7c, 0xa4, 0x80, 0x8a, 0x33, 0xf2, 0x23, 0xa2, 0x16, 0xeb, 0x8b, 0xb3, 0xb9, 0xd8,
+	0xe8, 0xaf, 0x4c, 0x43, 0x15, 0x09, 0xc7, 0x7e, 0x72, 0xd0, 0x58, 0x36, 0xed, 0xdb, 0x1c, 0x28,
+	0x1d, 0x04, 0x1e, 0xff, 0x75, 0xf9, 0xe7, 0x9d, 0xfa, 0xe3, 0x8c, 0x53, 0xdf, 0x98, 0xbe, 0x47,
+	0x51, 0xe2, 0x85, 0x26, 0xfd, 0xd9, 0x88, 0x49, 0xdf, 0x9a, 0x9d, 0xfa, 0xe9, 0xfe, 0xfc, 0x6b,
+	0x0e, 0x54, 0x44, 0x64, 0x62, 0xcd, 0x3b, 0x19, 0x6b, 0x7e, 0x79, 0xc4, 0x9a, 0xaf, 0x8c, 0xc6,
+	0x3f, 0x73, 0xe5, 0x11, 0x57, 0xfe, 0x49, 0x01, 0x65, 0x21, 0xd2, 0xbf, 0x60, 0xc8, 0x1f, 0x65,
+	0x0d, 0x79, 0x6b, 0xe6, 0x23, 0x72, 0x81, 0x17, 0xa3, 0xb8, 0x09, 0x6e, 0x7f, 0x89, 0x29, 0x29,
+	0x73, 0x9b, 0x92, 0xf6, 0x43, 0x1e, 0xac, 0x66, 0x0e, 0xe6, 0x7f, 0x72, 0xb4, 0xbb, 0x13, 0x46,
+	0xfb, 0x9d, 0x99, 0x65, 0x9f, 0x7e, 0xaa, 0xdf, 0xca, 0x4e, 0xf5, 0x8d, 0xd1, 0xa9, 0x2e, 0x6f,
+	0xf0, 0xff, 0x78, 0xa0, 0x37, 0xf4, 0xd3, 0xb3, 0xea, 0xc2, 0xe3, 0xb3, 0xea, 0xc2, 0x93, 0xb3,
+	0xea, 0xc2, 0xa3, 0x61, 0x55, 0x39, 0x1d, 0x56, 0x95, 0xc7, 0xc3, 0xaa, 0xf2, 0x64, 0x58, 0x55,
+	0x7e, 0x1f, 0x56, 0x95, 0x6f, 0xfe, 0xa8, 0x2e, 0x7c, 0xb2, 0x24, 0x93, 0xfd, 0x35, 0x00, 0xbe,
+	0xe7, 0x94, 0x2f, 0x63, 0x10, 0x00, 0x00,
+}
+
+func (m *MemberProvenance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberProvenance) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberProvenance) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sources) > 0 {
+		for iNdEx := len(m.Sources) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sources[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.Subject.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberSource) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberSource) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberSource) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.Name)
+	copy(dAtA[i:], m.Name)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Name)))
+	i--
+	dAtA[i] = 0x12
+	i -= len(m.Kind)
+	copy(dAtA[i:], m.Kind)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Kind)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
 }
 
 func (m *ObjectReference) Marshal() (dAtA []byte, err error) {
@@ -696,6 +844,20 @@ func (m *OrganizationSpec) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ExcludedOrganizationRoleTemplates) > 0 {
+		for iNdEx := len(m.ExcludedOrganizationRoleTemplates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ExcludedOrganizationRoleTemplates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.Owners) > 0 {
 		for iNdEx := len(m.Owners) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -745,6 +907,20 @@ func (m *OrganizationStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.MemberProvenance) > 0 {
+		for iNdEx := len(m.MemberProvenance) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MemberProvenance[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Members) > 0 {
 		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1006,6 +1182,20 @@ func (m *ProjectStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.MemberProvenance) > 0 {
+		for iNdEx := len(m.MemberProvenance) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MemberProvenance[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Members) > 0 {
 		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -1068,6 +1258,36 @@ func encodeVarintGenerated(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
+func (m *MemberProvenance) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Subject.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.Sources) > 0 {
+		for _, e := range m.Sources {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MemberSource) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Kind)
+	n += 1 + l + sovGenerated(uint64(l))
+	l = len(m.Name)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
 func (m *ObjectReference) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1159,6 +1379,12 @@ func (m *OrganizationSpec) Size() (n int) {
 			n += 1 + l + sovGenerated(uint64(l))
 		}
 	}
+	if len(m.ExcludedOrganizationRoleTemplates) > 0 {
+		for _, e := range m.ExcludedOrganizationRoleTemplates {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1187,6 +1413,12 @@ func (m *OrganizationStatus) Size() (n int) {
 			n += 1 + l + sovGenerated(uint64(l))
 		}
 	}
+	if len(m.MemberProvenance) > 0 {
+		for _, e := range m.MemberProvenance {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1281,6 +1513,12 @@ func (m *ProjectStatus) Size() (n int) {
 			n += 1 + l + sovGenerated(uint64(l))
 		}
 	}
+	if len(m.MemberProvenance) > 0 {
+		for _, e := range m.MemberProvenance {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1290,6 +1528,33 @@ func sovGenerated(x uint64) (n int) {
 func sozGenerated(x uint64) (n int) {
 	return sovGenerated(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
+func (this *MemberProvenance) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSources := "[]MemberSource{"
+	for _, f := range this.Sources {
+		repeatedStringForSources += strings.Replace(strings.Replace(f.String(), "MemberSource", "MemberSource", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForSources += "}"
+	s := strings.Join([]string{`&MemberProvenance{`,
+		`Subject:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.Subject), "Subject", "v1.Subject", 1), `&`, ``, 1) + `,`,
+		`Sources:` + repeatedStringForSources + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MemberSource) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MemberSource{`,
+		`Kind:` + fmt.Sprintf("%v", this.Kind) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *ObjectReference) String() string {
 	if this == nil {
 		return "nil"
@@ -1305,7 +1570,7 @@ func (this *Organization) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&Organization{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v11.ObjectMeta", 1), `&`, ``, 1) + `,`,
 		`Spec:` + strings.Replace(strings.Replace(this.Spec.String(), "OrganizationSpec", "OrganizationSpec", 1), `&`, ``, 1) + `,`,
 		`Status:` + strings.Replace(strings.Replace(this.Status.String(), "OrganizationStatus", "OrganizationStatus", 1), `&`, ``, 1) + `,`,
 		`}`,
@@ -1319,7 +1584,7 @@ func (this *OrganizationCondition) String() string {
 	s := strings.Join([]string{`&OrganizationCondition{`,
 		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
 		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`LastTransitionTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.LastTransitionTime), "Time", "v1.Time", 1), `&`, ``, 1) + `,`,
+		`LastTransitionTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.LastTransitionTime), "Time", "v11.Time", 1), `&`, ``, 1) + `,`,
 		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
 		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
 		`}`,
@@ -1336,7 +1601,7 @@ func (this *OrganizationList) String() string {
 	}
 	repeatedStringForItems += "}"
 	s := strings.Join([]string{`&OrganizationList{`,
-		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
+		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v11.ListMeta", 1), `&`, ``, 1) + `,`,
 		`Items:` + repeatedStringForItems + `,`,
 		`}`,
 	}, "")
@@ -1362,9 +1627,15 @@ func (this *OrganizationSpec) String() string {
 		repeatedStringForOwners += fmt.Sprintf("%v", f) + ","
 	}
 	repeatedStringForOwners += "}"
+	repeatedStringForExcludedOrganizationRoleTemplates := "[]ObjectReference{"
+	for _, f := range this.ExcludedOrganizationRoleTemplates {
+		repeatedStringForExcludedOrganizationRoleTemplates += strings.Replace(strings.Replace(f.String(), "ObjectReference", "ObjectReference", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForExcludedOrganizationRoleTemplates += "}"
 	s := strings.Join([]string{`&OrganizationSpec{`,
 		`Metadata:` + strings.Replace(this.Metadata.String(), "OrganizationMetadata", "OrganizationMetadata", 1) + `,`,
 		`Owners:` + repeatedStringForOwners + `,`,
+		`ExcludedOrganizationRoleTemplates:` + repeatedStringForExcludedOrganizationRoleTemplates + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1383,12 +1654,18 @@ func (this *OrganizationStatus) String() string {
 		repeatedStringForMembers += fmt.Sprintf("%v", f) + ","
 	}
 	repeatedStringForMembers += "}"
+	repeatedStringForMemberProvenance := "[]MemberProvenance{"
+	for _, f := range this.MemberProvenance {
+		repeatedStringForMemberProvenance += strings.Replace(strings.Replace(f.String(), "MemberProvenance", "MemberProvenance", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForMemberProvenance += "}"
 	s := strings.Join([]string{`&OrganizationStatus{`,
 		`Namespace:` + strings.Replace(this.Namespace.String(), "ObjectReference", "ObjectReference", 1) + `,`,
 		`ObservedGeneration:` + fmt.Sprintf("%v", this.ObservedGeneration) + `,`,
 		`Conditions:` + repeatedStringForConditions + `,`,
 		`Phase:` + fmt.Sprintf("%v", this.Phase) + `,`,
 		`Members:` + repeatedStringForMembers + `,`,
+		`MemberProvenance:` + repeatedStringForMemberProvenance + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1398,7 +1675,7 @@ func (this *Project) String() string {
 		return "nil"
 	}
 	s := strings.Join([]string{`&Project{`,
-		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v11.ObjectMeta", 1), `&`, ``, 1) + `,`,
 		`Spec:` + strings.Replace(strings.Replace(this.Spec.String(), "ProjectSpec", "ProjectSpec", 1), `&`, ``, 1) + `,`,
 		`Status:` + strings.Replace(strings.Replace(this.Status.String(), "ProjectStatus", "ProjectStatus", 1), `&`, ``, 1) + `,`,
 		`}`,
@@ -1412,7 +1689,7 @@ func (this *ProjectCondition) String() string {
 	s := strings.Join([]string{`&ProjectCondition{`,
 		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
 		`Status:` + fmt.Sprintf("%v", this.Status) + `,`,
-		`LastTransitionTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.LastTransitionTime), "Time", "v1.Time", 1), `&`, ``, 1) + `,`,
+		`LastTransitionTime:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.LastTransitionTime), "Time", "v11.Time", 1), `&`, ``, 1) + `,`,
 		`Reason:` + fmt.Sprintf("%v", this.Reason) + `,`,
 		`Message:` + fmt.Sprintf("%v", this.Message) + `,`,
 		`}`,
@@ -1429,7 +1706,7 @@ func (this *ProjectList) String() string {
 	}
 	repeatedStringForItems += "}"
 	s := strings.Join([]string{`&ProjectList{`,
-		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v1.ListMeta", 1), `&`, ``, 1) + `,`,
+		`ListMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ListMeta), "ListMeta", "v11.ListMeta", 1), `&`, ``, 1) + `,`,
 		`Items:` + repeatedStringForItems + `,`,
 		`}`,
 	}, "")
@@ -1439,48 +1716,291 @@ func (this *ProjectSpec) String() string {
 	if this == nil {
 		return "nil"
 	}
-	repeatedStringForOwners := "[]Subject{"
-	for _, f := range this.Owners {
-		repeatedStringForOwners += fmt.Sprintf("%v", f) + ","
+	repeatedStringForOwners := "[]Subject{"
+	for _, f := range this.Owners {
+		repeatedStringForOwners += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForOwners += "}"
+	s := strings.Join([]string{`&ProjectSpec{`,
+		`Owners:` + repeatedStringForOwners + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ProjectStatus) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForConditions := "[]ProjectCondition{"
+	for _, f := range this.Conditions {
+		repeatedStringForConditions += strings.Replace(strings.Replace(f.String(), "ProjectCondition", "ProjectCondition", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForConditions += "}"
+	repeatedStringForMembers := "[]Subject{"
+	for _, f := range this.Members {
+		repeatedStringForMembers += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForMembers += "}"
+	repeatedStringForMemberProvenance := "[]MemberProvenance{"
+	for _, f := range this.MemberProvenance {
+		repeatedStringForMemberProvenance += strings.Replace(strings.Replace(f.String(), "MemberProvenance", "MemberProvenance", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForMemberProvenance += "}"
+	s := strings.Join([]string{`&ProjectStatus{`,
+		`Namespace:` + strings.Replace(this.Namespace.String(), "ObjectReference", "ObjectReference", 1) + `,`,
+		`ObservedGeneration:` + fmt.Sprintf("%v", this.ObservedGeneration) + `,`,
+		`Conditions:` + repeatedStringForConditions + `,`,
+		`Phase:` + fmt.Sprintf("%v", this.Phase) + `,`,
+		`Members:` + repeatedStringForMembers + `,`,
+		`MemberProvenance:` + repeatedStringForMemberProvenance + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringGenerated(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *MemberProvenance) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberProvenance: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberProvenance: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subject", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Subject.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sources", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sources = append(m.Sources, MemberSource{})
+			if err := m.Sources[len(m.Sources)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	repeatedStringForOwners += "}"
-	s := strings.Join([]string{`&ProjectSpec{`,
-		`Owners:` + repeatedStringForOwners + `,`,
-		`}`,
-	}, "")
-	return s
+	return nil
 }
-func (this *ProjectStatus) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForConditions := "[]ProjectCondition{"
-	for _, f := range this.Conditions {
-		repeatedStringForConditions += strings.Replace(strings.Replace(f.String(), "ProjectCondition", "ProjectCondition", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForConditions += "}"
-	repeatedStringForMembers := "[]Subject{"
-	for _, f := range this.Members {
-		repeatedStringForMembers += fmt.Sprintf("%v", f) + ","
+func (m *MemberSource) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberSource: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberSource: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = MemberSourceKind(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	repeatedStringForMembers += "}"
-	s := strings.Join([]string{`&ProjectStatus{`,
-		`Namespace:` + strings.Replace(this.Namespace.String(), "ObjectReference", "ObjectReference", 1) + `,`,
-		`ObservedGeneration:` + fmt.Sprintf("%v", this.ObservedGeneration) + `,`,
-		`Conditions:` + repeatedStringForConditions + `,`,
-		`Phase:` + fmt.Sprintf("%v", this.Phase) + `,`,
-		`Members:` + repeatedStringForMembers + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringGenerated(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	return nil
 }
 func (m *ObjectReference) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -2264,11 +2784,45 @@ func (m *OrganizationSpec) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owners = append(m.Owners, v11.Subject{})
+			m.Owners = append(m.Owners, v1.Subject{})
 			if err := m.Owners[len(m.Owners)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludedOrganizationRoleTemplates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExcludedOrganizationRoleTemplates = append(m.ExcludedOrganizationRoleTemplates, ObjectReference{})
+			if err := m.ExcludedOrganizationRoleTemplates[len(m.ExcludedOrganizationRoleTemplates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -2472,11 +3026,45 @@ func (m *OrganizationStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, v11.Subject{})
+			m.Members = append(m.Members, v1.Subject{})
 			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberProvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MemberProvenance = append(m.MemberProvenance, MemberProvenance{})
+			if err := m.MemberProvenance[len(m.MemberProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -3045,7 +3633,7 @@ func (m *ProjectSpec) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Owners = append(m.Owners, v11.Subject{})
+			m.Owners = append(m.Owners, v1.Subject{})
 			if err := m.Owners[len(m.Owners)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -3253,11 +3841,45 @@ func (m *ProjectStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, v11.Subject{})
+			m.Members = append(m.Members, v1.Subject{})
 			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberProvenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MemberProvenance = append(m.MemberProvenance, MemberProvenance{})
+			if err := m.MemberProvenance[len(m.MemberProvenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
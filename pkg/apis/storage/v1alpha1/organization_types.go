@@ -28,6 +28,8 @@ type OrganizationSpec struct {
 	// Owners holds the RBAC subjects that represent the owners of this organization.
 	// +kubebuilder:validation:MinItems=1
 	Owners []rbacv1.Subject `json:"owners" protobuf:"bytes,2,rep,name=owners"`
+	// ExcludedOrganizationRoleTemplates lists OrganizationRoleTemplates that are not applied to this Organization and its Projects.
+	ExcludedOrganizationRoleTemplates []ObjectReference `json:"excludedOrganizationRoleTemplates,omitempty" protobuf:"bytes,3,rep,name=excludedOrganizationRoleTemplates"`
 }
 
 // OrganizationMetadata contains the metadata of the Organization.
@@ -57,6 +59,8 @@ type OrganizationStatus struct {
 
 	// Members enumerate all rbacv1.Subject mentioned in the Organization RoleBinding's
 	Members []rbacv1.Subject `json:"members,omitempty" protobuf:"bytes,5,rep,name=members"`
+	// MemberProvenance records the owners, RoleBindings and Projects that grant each of the Members.
+	MemberProvenance []MemberProvenance `json:"memberProvenance,omitempty" protobuf:"bytes,6,rep,name=memberProvenance"`
 }
 
 // OrganizationPhaseType represents all conditions as a single string for printing by using kubectl commands.
@@ -73,6 +77,15 @@ const (
 
 const (
 	OrganizationTerminatingReason = "Deleting"
+	// OrganizationNamespaceConflictReason is used when the Namespace of the Organization exists, but was not created for it.
+	OrganizationNamespaceConflictReason = "NamespaceConflict"
+)
+
+const (
+	// OrganizationNameLabel holds the user facing name of an Organization.
+	// Organizations are cluster-scoped and created with a generated name,
+	// so users can pick names without clashing with Organizations they can't see.
+	OrganizationNameLabel = "bulward.io/name"
 )
 
 // updatePhase updates the phase property based on the current conditions.
@@ -108,11 +121,13 @@ type OrganizationConditionType string
 const (
 	// OrganizationReady represents a Organization condition is in ready state.
 	OrganizationReady OrganizationConditionType = "Ready"
+	// OrganizationNamespaceConflict represents a Organization condition that its Namespace is already taken by someone else.
+	OrganizationNamespaceConflict OrganizationConditionType = "NamespaceConflict"
 )
 
 // OrganizationCondition contains details for the current condition of this Organization.
 type OrganizationCondition struct {
-	// Type is the type of the Organization condition, currently ('Ready').
+	// Type is the type of the Organization condition, currently ('Ready', 'NamespaceConflict').
 	Type OrganizationConditionType `json:"type" protobuf:"bytes,1,opt,name=type,casttype=OrganizationConditionType"`
 	// Status is the status of the condition, one of ('True', 'False', 'Unknown').
 	Status ConditionStatus `json:"status" protobuf:"bytes,2,opt,name=status,casttype=ConditionStatus"`
@@ -198,6 +213,25 @@ func (s *Organization) IsReady() bool {
 	return false
 }
 
+// ExcludesOrganizationRoleTemplate returns if the Organization opted out of the OrganizationRoleTemplate with the given name.
+func (s *Organization) ExcludesOrganizationRoleTemplate(name string) bool {
+	for _, template := range s.Spec.ExcludedOrganizationRoleTemplates {
+		if template.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Alias returns the user facing name of the Organization.
+// Organizations without the OrganizationNameLabel are addressed by their name.
+func (s *Organization) Alias() string {
+	if alias, ok := s.Labels[OrganizationNameLabel]; ok && alias != "" {
+		return alias
+	}
+	return s.Name
+}
+
 // OrganizationList contains a list of Organization.
 // +kubebuilder:object:root=true
 type OrganizationList struct {
@@ -88,6 +88,8 @@ type ProjectStatus struct {
 
 	// Members enumerate all rbacv1.Subject mentioned in the Project's RoleBinding's
 	Members []rbacv1.Subject `json:"members,omitempty" protobuf:"bytes,5,rep,name=members"`
+	// MemberProvenance records the owners and RoleBindings that grant each of the Members.
+	MemberProvenance []MemberProvenance `json:"memberProvenance,omitempty" protobuf:"bytes,6,rep,name=memberProvenance"`
 }
 
 // ProjectPhaseType represents all conditions as a single string for printing by using kubectl commands.
@@ -104,6 +106,8 @@ const (
 
 const (
 	ProjectTerminatingReason = "Deleting"
+	// ProjectNamespaceConflictReason is used when the Namespace of the Project exists, but was not created for it.
+	ProjectNamespaceConflictReason = "NamespaceConflict"
 )
 
 // updatePhase updates the phase property based on the current conditions.
@@ -139,11 +143,13 @@ type ProjectConditionType string
 const (
 	// OProjectReady represents a Project condition is in ready state.
 	ProjectReady ProjectConditionType = "Ready"
+	// ProjectNamespaceConflict represents a Project condition that its Namespace is already taken by someone else.
+	ProjectNamespaceConflict ProjectConditionType = "NamespaceConflict"
 )
 
 // ProjectCondition contains details for the current condition of this Project.
 type ProjectCondition struct {
-	// Type is the type of the Project condition, currently ('Ready').
+	// Type is the type of the Project condition, currently ('Ready', 'NamespaceConflict').
 	Type ProjectConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
 	// Status is the status of the condition, one of ('True', 'False', 'Unknown').
 	Status ConditionStatus `json:"status" protobuf:"bytes,2,opt,name=status"`
@@ -16,6 +16,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
 // ConditionStatus represents a condition's status.
 // +kubebuilder:validation:True;False;Unknown
 type ConditionStatus string
@@ -40,3 +44,34 @@ type ObjectReference struct {
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name" protobuf:"string,1,opt,name=name"`
 }
+
+// MemberSourceKind is the kind of object granting a subject the membership of an Organization or Project.
+// +kubebuilder:validation:Enum=Owner;RoleBinding;Project
+type MemberSourceKind string
+
+// Values of MemberSourceKind.
+const (
+	// MemberSourceOwner is used for subjects listed as owners of the Organization or Project.
+	MemberSourceOwner MemberSourceKind = "Owner"
+	// MemberSourceRoleBinding is used for subjects of user created RoleBindings in the Namespace.
+	MemberSourceRoleBinding MemberSourceKind = "RoleBinding"
+	// MemberSourceProject is used for members of Projects, that are propagated to their Organization.
+	MemberSourceProject MemberSourceKind = "Project"
+)
+
+// MemberSource references the object granting a subject its membership.
+type MemberSource struct {
+	// Kind of the object granting the membership, one of ('Owner', 'RoleBinding', 'Project').
+	Kind MemberSourceKind `json:"kind" protobuf:"bytes,1,opt,name=kind,casttype=MemberSourceKind"`
+	// Name of the object granting the membership, empty for owners.
+	Name string `json:"name,omitempty" protobuf:"bytes,2,opt,name=name"`
+}
+
+// MemberProvenance lists all objects granting a subject its membership.
+// A subject stays a member, as long as any of its sources exist.
+type MemberProvenance struct {
+	// Subject is the member.
+	Subject rbacv1.Subject `json:"subject" protobuf:"bytes,1,opt,name=subject"`
+	// Sources are the objects granting the membership.
+	Sources []MemberSource `json:"sources" protobuf:"bytes,2,rep,name=sources"`
+}
@@ -25,6 +25,42 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberProvenance) DeepCopyInto(out *MemberProvenance) {
+	*out = *in
+	out.Subject = in.Subject
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]MemberSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberProvenance.
+func (in *MemberProvenance) DeepCopy() *MemberProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberSource) DeepCopyInto(out *MemberSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberSource.
+func (in *MemberSource) DeepCopy() *MemberSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
@@ -143,6 +179,11 @@ func (in *OrganizationSpec) DeepCopyInto(out *OrganizationSpec) {
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludedOrganizationRoleTemplates != nil {
+		in, out := &in.ExcludedOrganizationRoleTemplates, &out.ExcludedOrganizationRoleTemplates
+		*out = make([]ObjectReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSpec.
@@ -175,6 +216,13 @@ func (in *OrganizationStatus) DeepCopyInto(out *OrganizationStatus) {
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.MemberProvenance != nil {
+		in, out := &in.MemberProvenance, &out.MemberProvenance
+		*out = make([]MemberProvenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationStatus.
@@ -302,6 +350,13 @@ func (in *ProjectStatus) DeepCopyInto(out *ProjectStatus) {
 		*out = make([]v1.Subject, len(*in))
 		copy(*out, *in)
 	}
+	if in.MemberProvenance != nil {
+		in, out := &in.MemberProvenance, &out.MemberProvenance
+		*out = make([]MemberProvenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectStatus.
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/apiserver/pkg/server/healthz"
@@ -37,13 +38,17 @@ import (
 	"k8c.io/bulward/pkg/apis"
 	apiserverapi "k8c.io/bulward/pkg/apis/apiserver"
 	apiserverv1alpha1 "k8c.io/bulward/pkg/apis/apiserver/v1alpha1"
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
 	"k8c.io/bulward/pkg/openapi"
+	"k8c.io/bulward/pkg/validation"
 )
 
 type flags struct {
 	bulwardSystemNamespace string
 	metricsAddr            string
+	reservedNames          []string
+	reservedNamePrefixes   []string
 }
 
 const (
@@ -53,6 +58,8 @@ const (
 func init() {
 	// due to apiserver-builder-alpha usage we must use the following scheme
 	utilruntime.Must(clientgoscheme.AddToScheme(builders.Scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(builders.Scheme))
+	utilruntime.Must(corev1alpha1.AddToScheme(builders.Scheme))
 	utilruntime.Must(storagev1alpha1.AddToScheme(builders.Scheme))
 	utilruntime.Must(apiserverapi.AddToScheme(builders.Scheme))
 	utilruntime.Must(apiserverv1alpha1.AddToScheme(builders.Scheme))
@@ -60,9 +67,15 @@ func init() {
 
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get
 // +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=organizations,verbs=create;get;list;watch;update;patch;delete
-// +kubebuilder:rbac:groups=storage.bulward.io,resources=projects,verbs=create;get;list;watch;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=storage.bulward.io,resources=projects,verbs=create;get;list;watch;update;patch;delete
+// The following permissions are needed to preview role templates via TemplatePreviews.
+// +kubebuilder:rbac:groups=bulward.io,resources=organizationroletemplates;projectroletemplates,verbs=get
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=list
 
 func NewAPIServerCommand() *cobra.Command {
 	log := ctrl.Log.WithName("apiserver")
@@ -118,6 +131,10 @@ func NewAPIServerCommand() *cobra.Command {
 		if err != nil {
 			return err
 		}
+		reservedNames := validation.ReservedNames{
+			Names:    flags.reservedNames,
+			Prefixes: flags.reservedNamePrefixes,
+		}
 		// Organization
 		if err := apiserverapi.OrganizationRESTSingleton.InjectMapper(mapper); err != nil {
 			return err
@@ -131,6 +148,9 @@ func NewAPIServerCommand() *cobra.Command {
 		if err := apiserverapi.OrganizationRESTSingleton.InjectScheme(builders.Scheme); err != nil {
 			return err
 		}
+		if err := apiserverapi.OrganizationRESTSingleton.InjectReservedNames(reservedNames); err != nil {
+			return err
+		}
 		// Project
 		if err := apiserverapi.ProjectRESTSingleton.InjectMapper(mapper); err != nil {
 			return err
@@ -144,10 +164,23 @@ func NewAPIServerCommand() *cobra.Command {
 		if err := apiserverapi.ProjectRESTSingleton.InjectScheme(builders.Scheme); err != nil {
 			return err
 		}
+		if err := apiserverapi.ProjectRESTSingleton.InjectReservedNames(reservedNames); err != nil {
+			return err
+		}
+		// TemplatePreview
+		if err := apiserverapi.TemplatePreviewRESTSingleton.InjectClient(k8sClient); err != nil {
+			return err
+		}
+		if err := apiserverapi.TemplatePreviewRESTSingleton.InjectScheme(builders.Scheme); err != nil {
+			return err
+		}
 		return nil
 	}
 	cmd.Flags().StringVar(&flags.metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	cmd.Flags().StringVar(&flags.bulwardSystemNamespace, "bulward-system-namespace", os.Getenv("BULWARD_NAMESPACE"), "The namespace that Bulward controller manager deploys to.")
+	defaultReservedNames := validation.DefaultReservedNames()
+	cmd.Flags().StringSliceVar(&flags.reservedNames, "reserved-names", defaultReservedNames.Names, "Names that can't be used for Organizations and Projects.")
+	cmd.Flags().StringSliceVar(&flags.reservedNamePrefixes, "reserved-name-prefixes", defaultReservedNames.Prefixes, "Name prefixes that can't be used for Organizations and Projects.")
 	return cmd
 }
 func filterHealthChecks(in []healthz.HealthChecker, exclude string) []healthz.HealthChecker {
@@ -19,9 +19,29 @@ package controllers
 import (
 	"sort"
 
+	"k8c.io/utils/pkg/owner"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
 )
 
+// templateOwnerTypes are the owner types of RoleBindings that are managed by role templates.
+var templateOwnerTypes = map[string]bool{
+	corev1alpha1.GroupVersion.WithKind("OrganizationRoleTemplate").GroupKind().String(): true,
+	corev1alpha1.GroupVersion.WithKind("ProjectRoleTemplate").GroupKind().String():      true,
+}
+
+// isManagedByTemplate checks if the object was created by Bulward for a role template.
+// Subjects of such RoleBindings are derived from the members, so they must not be counted as members again.
+// Owner labels and references can be set by anyone creating the object, only the ManagedLabel is reserved
+// for the manager by the ManagedObjectWebhookHandler, so it's required as well.
+func isManagedByTemplate(obj metav1.Object) bool {
+	labels := obj.GetLabels()
+	return labels[corev1alpha1.ManagedLabel] == "true" && templateOwnerTypes[labels[owner.OwnerTypeLabel]]
+}
+
 func extractSubjects(subjects []rbacv1.Subject) []rbacv1.Subject {
 	sort.Slice(subjects, func(i, j int) bool {
 		a := subjects[i]
@@ -36,3 +56,57 @@ func extractSubjects(subjects []rbacv1.Subject) []rbacv1.Subject {
 	}
 	return filteredSubjects
 }
+
+// memberSet collects the members of an Organization or Project together with the objects granting their membership.
+type memberSet map[string]*storagev1alpha1.MemberProvenance
+
+// add records the given source for all subjects.
+func (s memberSet) add(source storagev1alpha1.MemberSource, subjects ...rbacv1.Subject) {
+	for _, subject := range subjects {
+		key := subject.String()
+		provenance, ok := s[key]
+		if !ok {
+			provenance = &storagev1alpha1.MemberProvenance{Subject: subject}
+			s[key] = provenance
+		}
+		if !hasMemberSource(provenance.Sources, source) {
+			provenance.Sources = append(provenance.Sources, source)
+		}
+	}
+}
+
+// members returns the sorted subjects of the memberSet.
+func (s memberSet) members() []rbacv1.Subject {
+	subjects := make([]rbacv1.Subject, 0, len(s))
+	for _, provenance := range s {
+		subjects = append(subjects, provenance.Subject)
+	}
+	return extractSubjects(subjects)
+}
+
+// provenance returns the sources of all members, in the same order as members.
+func (s memberSet) provenance() []storagev1alpha1.MemberProvenance {
+	out := make([]storagev1alpha1.MemberProvenance, 0, len(s))
+	for _, subject := range s.members() {
+		provenance := *s[subject.String()]
+		sort.Slice(provenance.Sources, func(i, j int) bool {
+			a := provenance.Sources[i]
+			b := provenance.Sources[j]
+			if a.Kind != b.Kind {
+				return a.Kind < b.Kind
+			}
+			return a.Name < b.Name
+		})
+		out = append(out, provenance)
+	}
+	return out
+}
+
+func hasMemberSource(sources []storagev1alpha1.MemberSource, source storagev1alpha1.MemberSource) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
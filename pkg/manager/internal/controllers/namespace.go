@@ -0,0 +1,68 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8c.io/utils/pkg/owner"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceConflictRequeueInterval is the interval to check again whether a Namespace conflict has been resolved,
+// as the conflicting Namespace is not owned by us and changes to it are not enqueued.
+const namespaceConflictRequeueInterval = time.Minute
+
+// checkNamespaceConflict checks if the Namespace with the given name exists without being owned by the given owner.
+// Such a Namespace was not created by Bulward for this owner and must never be adopted.
+func checkNamespaceConflict(ctx context.Context, c client.Reader, scheme *runtime.Scheme, ownerObj runtime.Object, name string) (conflict bool, err error) {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting Namespace: %w", err)
+	}
+	return !isOwnedBy(ns, ownerObj, scheme), nil
+}
+
+// isOwnedBy checks if the object carries the owner labels of the given owner.
+func isOwnedBy(obj, ownerObj runtime.Object, scheme *runtime.Scheme) bool {
+	// SetOwnerReference only reports no change if all owner labels are already present,
+	// and fails if the object is owned by someone else.
+	changed, err := owner.SetOwnerReference(ownerObj, obj.DeepCopyObject(), scheme)
+	return err == nil && !changed
+}
+
+// updateNamespaceLabels adds the labels of the desired Namespace to the existing one, keeping labels set by others.
+func updateNamespaceLabels(actual, desired runtime.Object) error {
+	actualNamespace := actual.(*corev1.Namespace)
+	desiredNamespace := desired.(*corev1.Namespace)
+	if actualNamespace.Labels == nil {
+		actualNamespace.Labels = map[string]string{}
+	}
+	for k, v := range desiredNamespace.Labels {
+		actualNamespace.Labels[k] = v
+	}
+	return nil
+}
@@ -25,44 +25,58 @@ import (
 	"k8c.io/utils/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
-	"k8c.io/bulward/pkg/templates"
+	"k8c.io/bulward/pkg/naming"
 )
 
 const (
 	organizationControllerFinalizer string = "organization.bulward.io/controller"
+
+	// DefaultRoleTemplateConflictReason is the Event reason used, when an OrganizationRoleTemplate has the name of a default,
+	// but is not managed as default.
+	DefaultRoleTemplateConflictReason = "DefaultRoleTemplateConflict"
 )
 
 // OrganizationReconciler reconciles a Organization object
 type OrganizationReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	NamespacePolicy *naming.NamespacePolicy
+	// DefaultOrganizationRoleTemplates is the default set of OrganizationRoleTemplates, that is kept up-to-date in the cluster.
+	DefaultOrganizationRoleTemplates []*corev1alpha1.OrganizationRoleTemplate
 }
 
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=organizations,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=organizations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=projects,verbs=get;list;watch
-// +kubebuilder:rbac:groups=bulward.io,resources=organizationroletemplates,verbs=create
+// +kubebuilder:rbac:groups=bulward.io,resources=organizationroletemplates,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=bulward.io,resources=projectroletemplates,verbs=create
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile function reconciles the Organization object which specified by the request. Currently, it does the following:
 // 1. Fetch the Organization object.
 // 2. Handle the deletion of the Organization object (Remove the namespace that the Organization owns, and remove the finalizer).
 // 3. Handle the creation/update of the Organization object (Create/reconcile the namespace and insert the finalizer).
-// 4. Create project-admin and rbac-admin OrganizationRoleTemplate for owners of the Organization.
-// 5. Update the status of the Organization object.
+// 4. Report a NamespaceConflict instead of adopting a namespace that was not created for this Organization.
+// 5. Create project-admin and rbac-admin OrganizationRoleTemplate for owners of the Organization.
+// 6. Update the status of the Organization object.
 func (r *OrganizationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
 	log := r.Log.WithValues("Organization", req.NamespacedName)
@@ -85,14 +99,18 @@ func (r *OrganizationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error
 		}
 	}
 
-	if err := r.reconcileNamespace(ctx, log, organization); err != nil {
+	conflict, err := r.reconcileNamespace(ctx, log, organization)
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("reconciling namespace: %w", err)
 	}
+	if conflict {
+		return ctrl.Result{RequeueAfter: namespaceConflictRequeueInterval}, nil
+	}
 	if err := r.reconcileMembers(ctx, log, organization); err != nil {
 		return ctrl.Result{}, fmt.Errorf("reconciling members: %w", err)
 	}
-	if err := r.checkOrganizationRoleTemplatesForOwners(ctx); err != nil {
-		return ctrl.Result{}, fmt.Errorf("checking default OrganizationRoleTemplates: %w", err)
+	if err := r.reconcileDefaultOrganizationRoleTemplates(ctx); err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling default OrganizationRoleTemplates: %w", err)
 	}
 
 	if !organization.IsReady() {
@@ -111,13 +129,18 @@ func (r *OrganizationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error
 }
 
 func (r *OrganizationReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	enqueuerForOwner := owner.EnqueueRequestForOwner(&storagev1alpha1.Organization{}, mgr.GetScheme())
+	enqueuerForOwner := owner.EnqueueRequestForOwner(&storagev1alpha1.Organization{}, mgr.GetScheme()).(*handler.EnqueueRequestsFromMapFunc)
 	enqueuerByNamespace := &handler.EnqueueRequestsFromMapFunc{
 		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
-			return []reconcile.Request{{
-				// Organization name is its namespace
-				NamespacedName: types.NamespacedName{Name: object.Meta.GetNamespace()},
-			}}
+			// The namespace name is determined by the NamespacePolicy, so the Organization is looked up via the namespace owner.
+			ns := &corev1.Namespace{}
+			if err := r.Client.Get(context.Background(), types.NamespacedName{Name: object.Meta.GetNamespace()}, ns); err != nil {
+				return nil
+			}
+			return enqueuerForOwner.ToRequests.Map(handler.MapObject{
+				Meta:   ns,
+				Object: ns,
+			})
 		}),
 	}
 
@@ -161,33 +184,103 @@ func (r *OrganizationReconciler) handleDeletion(ctx context.Context, log logr.Lo
 	return nil
 }
 
-func (r *OrganizationReconciler) reconcileNamespace(ctx context.Context, log logr.Logger, organization *storagev1alpha1.Organization) error {
+func (r *OrganizationReconciler) reconcileNamespace(ctx context.Context, log logr.Logger, organization *storagev1alpha1.Organization) (conflict bool, err error) {
+	nsName, err := r.namespaceName(organization)
+	if err != nil {
+		return false, err
+	}
+	// The user facing name is kept as label to make the namespace discoverable.
 	ns := &corev1.Namespace{}
-	ns.Name = organization.Name
+	ns.Name = nsName
+	ns.Labels = map[string]string{
+		storagev1alpha1.OrganizationNameLabel: organization.Alias(),
+		corev1alpha1.ManagedLabel:             "true",
+	}
 
-	if _, err := owner.ReconcileOwnedObjects(ctx, r.Client, log, r.Scheme, organization, []runtime.Object{ns}, &corev1.Namespace{}, nil); err != nil {
-		return fmt.Errorf("cannot reconcile namespace: %w", err)
+	conflict, err = checkNamespaceConflict(ctx, r.Client, r.Scheme, organization, ns.Name)
+	if err != nil {
+		return false, err
+	}
+	if conflict {
+		log.Info("namespace already exists and is not owned by this Organization", "namespace", ns.Name)
+		organization.Status.ObservedGeneration = organization.Generation
+		organization.Status.SetCondition(storagev1alpha1.OrganizationCondition{
+			Type:    storagev1alpha1.OrganizationNamespaceConflict,
+			Status:  storagev1alpha1.ConditionTrue,
+			Reason:  storagev1alpha1.OrganizationNamespaceConflictReason,
+			Message: fmt.Sprintf("Namespace %s already exists and was not created for this Organization.", ns.Name),
+		})
+		organization.Status.SetCondition(storagev1alpha1.OrganizationCondition{
+			Type:    storagev1alpha1.OrganizationReady,
+			Status:  storagev1alpha1.ConditionFalse,
+			Reason:  storagev1alpha1.OrganizationNamespaceConflictReason,
+			Message: fmt.Sprintf("Namespace %s is taken.", ns.Name),
+		})
+		if err := r.Status().Update(ctx, organization); err != nil {
+			return false, fmt.Errorf("updating Organization status: %w", err)
+		}
+		return true, nil
 	}
 
 	if organization.Status.Namespace == nil {
+		// Record the name before creating the Namespace, so it is never renamed later on.
 		organization.Status.Namespace = &storagev1alpha1.ObjectReference{
 			Name: ns.Name,
 		}
 		if err := r.Status().Update(ctx, organization); err != nil {
-			return fmt.Errorf("updating NamespaceName: %w", err)
+			return false, fmt.Errorf("updating NamespaceName: %w", err)
 		}
 	}
-	return nil
+
+	if _, err := owner.ReconcileOwnedObjects(ctx, r.Client, log, r.Scheme, organization, []runtime.Object{ns}, &corev1.Namespace{}, updateNamespaceLabels); err != nil {
+		return false, fmt.Errorf("cannot reconcile namespace: %w", err)
+	}
+
+	if conflictCondition, _ := organization.Status.GetCondition(storagev1alpha1.OrganizationNamespaceConflict); conflictCondition.Status == storagev1alpha1.ConditionTrue {
+		organization.Status.SetCondition(storagev1alpha1.OrganizationCondition{
+			Type:    storagev1alpha1.OrganizationNamespaceConflict,
+			Status:  storagev1alpha1.ConditionFalse,
+			Reason:  "NamespaceCreated",
+			Message: fmt.Sprintf("Namespace %s is owned by this Organization.", ns.Name),
+		})
+		if err := r.Status().Update(ctx, organization); err != nil {
+			return false, fmt.Errorf("updating Organization status: %w", err)
+		}
+	}
+	return false, nil
 }
 
+// namespaceName returns the Namespace name of the Organization. Once recorded in the status, the name is kept,
+// so changes of the NamespacePolicy don't affect existing Organizations.
+func (r *OrganizationReconciler) namespaceName(organization *storagev1alpha1.Organization) (string, error) {
+	if organization.Status.Namespace != nil {
+		return organization.Status.Namespace.Name, nil
+	}
+	name, err := r.NamespacePolicy.OrganizationNamespace(organization)
+	if err != nil {
+		return "", fmt.Errorf("building namespace name: %w", err)
+	}
+	return name, nil
+}
+
+// reconcileMembers computes the members of the Organization from its owners, user created RoleBindings and Project members.
+// RoleBindings managed by role templates are ignored, as their subjects are derived from the members themselves.
+// The source of every membership is recorded, so a subject is dropped as soon as the last object granting it is gone.
 func (r *OrganizationReconciler) reconcileMembers(ctx context.Context, log logr.Logger, organization *storagev1alpha1.Organization) error {
-	var subjects []rbacv1.Subject
+	members := memberSet{}
+	members.add(storagev1alpha1.MemberSource{Kind: storagev1alpha1.MemberSourceOwner}, organization.Spec.Owners...)
 	rbs := &rbacv1.RoleBindingList{}
 	if err := r.List(ctx, rbs, client.InNamespace(organization.Status.Namespace.Name)); err != nil {
 		return fmt.Errorf("list rolebindings: %w", err)
 	}
 	for _, roleBinding := range rbs.Items {
-		subjects = append(subjects, roleBinding.Subjects...)
+		if isManagedByTemplate(&roleBinding) {
+			continue
+		}
+		members.add(storagev1alpha1.MemberSource{
+			Kind: storagev1alpha1.MemberSourceRoleBinding,
+			Name: roleBinding.Name,
+		}, roleBinding.Subjects...)
 	}
 	// Propagate members of Project under this Organization.
 	projects := &storagev1alpha1.ProjectList{}
@@ -196,30 +289,104 @@ func (r *OrganizationReconciler) reconcileMembers(ctx context.Context, log logr.
 	}
 	for _, project := range projects.Items {
 		if project.IsReady() {
-			subjects = append(subjects, project.Status.Members...)
+			members.add(storagev1alpha1.MemberSource{
+				Kind: storagev1alpha1.MemberSourceProject,
+				Name: project.Name,
+			}, project.Status.Members...)
 		}
 	}
-	organization.Status.Members = extractSubjects(subjects)
+	organization.Status.Members = members.members()
+	organization.Status.MemberProvenance = members.provenance()
 	if err := r.Status().Update(ctx, organization); err != nil {
 		return fmt.Errorf("updating members: %w", err)
 	}
 	return nil
 }
 
-// checkOrganizationRoleTemplatesForOwners checks if the bulward pre-defined OrganizationRoleTemplates for Organization owners
-// (project-admin, rbac-admin) are present. If not, just create them.
-func (r *OrganizationReconciler) checkOrganizationRoleTemplatesForOwners(ctx context.Context) error {
-	ownerTemplates := templates.DefaultOrganizationRoleTemplatesForOwners()
-
-	for _, template := range ownerTemplates {
-		if err := r.Client.Create(ctx, template); err != nil {
-			if errors.IsAlreadyExists(err) {
-				// default OrganizationRoleTemplate is created by some other Organizations, and it will be shared across
-				// organizations in the system, so no need to create again.
-				continue
-			}
-			return fmt.Errorf("creating owner OrganizationRoleTemplate: %s: %w", template.Name, err)
+// reconcileDefaultOrganizationRoleTemplates creates and updates the default OrganizationRoleTemplates,
+// they are shared by all Organizations in the system.
+// Managed defaults, that were disabled or removed from the default set, are deleted.
+// Defaults marked as unmanaged are left alone, so admins can keep their own copy.
+func (r *OrganizationReconciler) reconcileDefaultOrganizationRoleTemplates(ctx context.Context) error {
+	desired := map[string]bool{}
+	for _, template := range r.DefaultOrganizationRoleTemplates {
+		desired[template.Name] = true
+		if err := r.reconcileDefaultOrganizationRoleTemplate(ctx, template); err != nil {
+			return fmt.Errorf("reconciling OrganizationRoleTemplate %s: %w", template.Name, err)
+		}
+	}
+
+	existing := &corev1alpha1.OrganizationRoleTemplateList{}
+	if err := r.List(ctx, existing, client.HasLabels{corev1alpha1.DefaultRoleTemplateLabel}); err != nil {
+		return fmt.Errorf("listing OrganizationRoleTemplates: %w", err)
+	}
+	for i := range existing.Items {
+		template := &existing.Items[i]
+		if desired[template.Name] || isUnmanagedRoleTemplate(template) {
+			continue
+		}
+		if err := r.Delete(ctx, template); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting OrganizationRoleTemplate %s: %w", template.Name, err)
+		}
+	}
+	return nil
+}
+
+// reconcileDefaultOrganizationRoleTemplate creates or updates a single default OrganizationRoleTemplate.
+// Existing templates of the same name are only updated, if they carry the default label.
+// Other templates of the same name belong to someone else, they are reported as conflict and left alone.
+func (r *OrganizationReconciler) reconcileDefaultOrganizationRoleTemplate(ctx context.Context, desired *corev1alpha1.OrganizationRoleTemplate) error {
+	template := &corev1alpha1.OrganizationRoleTemplate{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name}, template)
+	if errors.IsNotFound(err) {
+		template = desired.DeepCopy()
+		setDefaultRoleTemplateLabel(template)
+		if err := r.Create(ctx, template); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("creating: %w", err)
 		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("getting: %w", err)
+	}
+	if isUnmanagedRoleTemplate(template) {
+		return nil
+	}
+	if template.Labels[corev1alpha1.DefaultRoleTemplateLabel] != "true" {
+		r.Log.Info("OrganizationRoleTemplate conflicts with a default", "OrganizationRoleTemplate", template.Name)
+		r.Recorder.Eventf(template, corev1.EventTypeWarning, DefaultRoleTemplateConflictReason,
+			"Not replaced by the default of the same name, as it isn't labelled %s=true. Add the label to have it replaced by the default.", corev1alpha1.DefaultRoleTemplateLabel)
+		return nil
+	}
+
+	// Fields, that are not set in the default, are ignored, so the defaulting of the API server doesn't cause updates.
+	version := desired.Annotations[corev1alpha1.RoleTemplateVersionAnnotation]
+	if template.Annotations[corev1alpha1.RoleTemplateVersionAnnotation] == version &&
+		equality.Semantic.DeepDerivative(desired.Spec, template.Spec) {
+		return nil
+	}
+	if version != "" {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[corev1alpha1.RoleTemplateVersionAnnotation] = version
+	} else {
+		delete(template.Annotations, corev1alpha1.RoleTemplateVersionAnnotation)
+	}
+	template.Spec = *desired.Spec.DeepCopy()
+	if err := r.Update(ctx, template); err != nil {
+		return fmt.Errorf("updating: %w", err)
 	}
 	return nil
 }
+
+func setDefaultRoleTemplateLabel(template *corev1alpha1.OrganizationRoleTemplate) {
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[corev1alpha1.DefaultRoleTemplateLabel] = "true"
+}
+
+func isUnmanagedRoleTemplate(template *corev1alpha1.OrganizationRoleTemplate) bool {
+	return template.Annotations[corev1alpha1.RoleTemplateUnmanagedAnnotation] == "true"
+}
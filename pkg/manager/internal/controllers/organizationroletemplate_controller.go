@@ -18,29 +18,35 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/go-logr/logr"
+	"k8c.io/utils/pkg/owner"
 	rbacv1 "k8s.io/api/rbac/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/templates"
 )
 
 // OrganizationRoleTemplateReconciler reconciles a Organization object
 type OrganizationRoleTemplateReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=bulward.io,resources=organizationroletemplates,verbs=get;list;watch;update
@@ -51,9 +57,13 @@ type OrganizationRoleTemplateReconciler struct {
 // +kubebuilder:rbac:groups=apiserver.bulward.io,resources=projects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete;bind
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *OrganizationRoleTemplateReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
+	log := r.Log.WithValues("OrganizationRoleTemplate", req.NamespacedName)
 
 	organizationRoleTemplate := &corev1alpha1.OrganizationRoleTemplate{}
 	if err := r.Get(ctx, req.NamespacedName, organizationRoleTemplate); err != nil {
@@ -67,63 +77,89 @@ func (r *OrganizationRoleTemplateReconciler) Reconcile(req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, nil
 	}
 
-	var targets []corev1alpha1.RoleTemplateTarget
-	organizations := &storagev1alpha1.OrganizationList{}
-	if err := r.Client.List(ctx, organizations); err != nil {
-		return ctrl.Result{}, fmt.Errorf("listing Organizations: %w", err)
-	}
-
-	// Reconcile Role/RoleBindings in Organization namespaces.
-	if organizationRoleTemplate.HasScope(corev1alpha1.RoleTemplateScopeOrganization) {
-		for _, organization := range organizations.Items {
-			if !organization.IsReady() {
-				// skip Unready Organizations.
-				continue
+	desired, organizations, err := templates.OrganizationRoleTemplateTargets(ctx, r.Client, organizationRoleTemplate)
+	var cycle *templates.IncludeCycleError
+	if errors.As(err, &cycle) {
+		// Existing Roles and RoleBindings are kept, until the cycle is resolved by changing one of the included templates.
+		if setOrganizationRoleTemplateIncludeCycle(organizationRoleTemplate, cycle) {
+			if err := r.Status().Update(ctx, organizationRoleTemplate); err != nil {
+				return ctrl.Result{}, fmt.Errorf("updating OrganizationRoleTemplate status: %w", err)
 			}
-			if err := r.reconcileRBACForOrganization(ctx, organizationRoleTemplate, &organization); err != nil {
-				return ctrl.Result{}, fmt.Errorf("reconcling Organization RBAC: %w", err)
-			}
-			targets = append(targets, corev1alpha1.RoleTemplateTarget{
-				Kind:               organization.Kind,
-				APIGroup:           organization.GroupVersionKind().Group,
-				Name:               organization.Name,
-				ObservedGeneration: organization.Status.ObservedGeneration,
-			})
-
 		}
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("computing targets: %w", err)
+	}
+	targets := make([]roleTemplateTarget, 0, len(desired))
+	for _, target := range desired {
+		targets = append(targets, roleTemplateTarget{Target: target})
 	}
 
-	// Reconcile Role/RoleBindings in Project namespaces.
-	if organizationRoleTemplate.HasScope(corev1alpha1.RoleTemplateScopeProject) {
-		for _, organization := range organizations.Items {
-			if !organization.IsReady() {
-				continue
-			}
-			projects := &storagev1alpha1.ProjectList{}
-			if err := r.Client.List(ctx, projects, client.InNamespace(organization.Status.Namespace.Name)); err != nil {
-				return ctrl.Result{}, fmt.Errorf("listing Projects: %w", err)
-			}
-
-			for _, project := range projects.Items {
-				if !project.IsReady() {
-					continue
-				}
-				if err := r.reconcileRBACForProject(ctx, organizationRoleTemplate, &organization, &project); err != nil {
-					return ctrl.Result{}, fmt.Errorf("reconcling Project RBAC: %w", err)
-				}
-				targets = append(targets, corev1alpha1.RoleTemplateTarget{
-					Kind:               project.Kind,
-					APIGroup:           project.GroupVersionKind().Group,
-					Name:               project.Name,
-					ObservedGeneration: project.Status.ObservedGeneration,
-				})
-			}
-		}
+	revision, err := organizationRoleTemplateRevision(organizationRoleTemplate)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("computing revision: %w", err)
+	}
+	// Roll out Role/RoleBindings of every target on its own, so a single broken namespace doesn't block the others.
+	// Objects in Namespaces that left the scope of the OrganizationRoleTemplate are pruned.
+	rollout := &roleTemplateRollout{
+		Client:     r.Client,
+		Log:        log,
+		Scheme:     r.Scheme,
+		Recorder:   r.Recorder,
+		template:   organizationRoleTemplate,
+		controller: true,
+		revision:   revision,
+		previous:   organizationRoleTemplate.Status.Rollout,
 	}
+	// Hold back outdated Organizations, that are not part of the current batch of a staged rollout.
+	stagedRollout, requeueAfter, err := r.stageRollout(ctx, organizationRoleTemplate, revision, organizations, targets, rollout)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("staging rollout: %w", err)
+	}
+	rolloutStatus, conflicts, pruneErr := rollout.rollout(ctx, targets)
 
 	var changed bool
-	if !reflect.DeepEqual(targets, organizationRoleTemplate.Status.Targets) {
-		organizationRoleTemplate.Status.Targets = targets
+	if !reflect.DeepEqual(rolloutStatus, organizationRoleTemplate.Status.Rollout) {
+		organizationRoleTemplate.Status.Rollout = rolloutStatus
+		changed = true
+	}
+	if !reflect.DeepEqual(stagedRollout, organizationRoleTemplate.Status.StagedRollout) {
+		organizationRoleTemplate.Status.StagedRollout = stagedRollout
+		changed = true
+	}
+	degraded := corev1alpha1.OrganizationRoleTemplateCondition{
+		Type:    corev1alpha1.OrganizationRoleTemplateDegraded,
+		Status:  corev1alpha1.ConditionFalse,
+		Reason:  "RolloutComplete",
+		Message: rolloutMessage(rolloutStatus),
+	}
+	if rolloutStatus.Failed > 0 {
+		degraded.Status = corev1alpha1.ConditionTrue
+		degraded.Reason = corev1alpha1.OrganizationRoleTemplateRolloutFailedReason
+	}
+	if current, _ := organizationRoleTemplate.Status.GetCondition(corev1alpha1.OrganizationRoleTemplateDegraded); current.Status != degraded.Status ||
+		current.Reason != degraded.Reason || current.Message != degraded.Message {
+		organizationRoleTemplate.Status.SetCondition(degraded)
+		changed = true
+	}
+	// Roles and RoleBindings owned by someone else are never overwritten, the competing owner is reported instead.
+	conflict := corev1alpha1.OrganizationRoleTemplateCondition{
+		Type:    corev1alpha1.OrganizationRoleTemplateConflict,
+		Status:  corev1alpha1.ConditionFalse,
+		Reason:  "NoConflict",
+		Message: conflictMessage(conflicts),
+	}
+	if len(conflicts) > 0 {
+		conflict.Status = corev1alpha1.ConditionTrue
+		conflict.Reason = corev1alpha1.OrganizationRoleTemplateNameConflictReason
+	}
+	if current, _ := organizationRoleTemplate.Status.GetCondition(corev1alpha1.OrganizationRoleTemplateConflict); current.Status != conflict.Status ||
+		current.Reason != conflict.Reason || current.Message != conflict.Message {
+		organizationRoleTemplate.Status.SetCondition(conflict)
+		changed = true
+	}
+	if setOrganizationRoleTemplateIncludeCycle(organizationRoleTemplate, nil) {
 		changed = true
 	}
 	if !organizationRoleTemplate.IsReady() {
@@ -143,7 +179,13 @@ func (r *OrganizationRoleTemplateReconciler) Reconcile(req ctrl.Request) (ctrl.R
 			return ctrl.Result{}, fmt.Errorf("updating OrganizationRoleTemplate status: %w", err)
 		}
 	}
-	return ctrl.Result{}, nil
+	if pruneErr != nil {
+		return ctrl.Result{}, fmt.Errorf("pruning RBAC: %w", pruneErr)
+	}
+	if rolloutStatus.Failed > 0 {
+		return ctrl.Result{}, fmt.Errorf("rolling out RBAC: %s", rolloutMessage(rolloutStatus))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *OrganizationRoleTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -166,9 +208,77 @@ func (r *OrganizationRoleTemplateReconciler) SetupWithManager(mgr ctrl.Manager)
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1alpha1.OrganizationRoleTemplate{}).
+		// Status updates are skipped, as the rollout status is written by this controller.
+		For(&corev1alpha1.OrganizationRoleTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Templates are rendered again, when a template they directly or transitively include changes.
+		Watches(&source.Kind{Type: &corev1alpha1.OrganizationRoleTemplate{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) (out []ctrl.Request) {
+				organizationRoleTemplates := &corev1alpha1.OrganizationRoleTemplateList{}
+				if err := r.Client.List(context.Background(), organizationRoleTemplates); err != nil {
+					// This will makes the manager crashes, and it will restart and reconcile all objects again.
+					panic(fmt.Errorf("listting OrganizationRoleTemplate: %w", err))
+				}
+				graph := templates.IncludeGraph{}
+				for i := range organizationRoleTemplates.Items {
+					graph.AddOrganizationRoleTemplate(&organizationRoleTemplates.Items[i])
+				}
+				for _, includer := range graph.Includers(templates.TemplateKey{Kind: templates.OrganizationRoleTemplateKind, Name: mapObject.Meta.GetName()}) {
+					out = append(out, ctrl.Request{
+						NamespacedName: types.NamespacedName{
+							Name: includer.Name,
+						},
+					})
+				}
+				return
+			}),
+		}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&source.Kind{Type: &storagev1alpha1.Organization{}}, enqueueAllTemplates).
 		Watches(&source.Kind{Type: &storagev1alpha1.Project{}}, enqueueAllTemplates).
+		// Roles and RoleBindings changed or deleted by others are corrected right away, instead of on the next resync.
+		Watches(&source.Kind{Type: &rbacv1.Role{}}, owner.EnqueueRequestForOwner(&corev1alpha1.OrganizationRoleTemplate{}, mgr.GetScheme())).
+		Watches(&source.Kind{Type: &rbacv1.RoleBinding{}}, owner.EnqueueRequestForOwner(&corev1alpha1.OrganizationRoleTemplate{}, mgr.GetScheme())).
+		// Copied rules are rolled out again, when the referenced ClusterRole changes.
+		Watches(&source.Kind{Type: &rbacv1.ClusterRole{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) (out []ctrl.Request) {
+				templates := &corev1alpha1.OrganizationRoleTemplateList{}
+				if err := r.Client.List(context.Background(), templates); err != nil {
+					// This will makes the manager crashes, and it will restart and reconcile all objects again.
+					panic(fmt.Errorf("listting OrganizationRoleTemplate: %w", err))
+				}
+				for _, template := range templates.Items {
+					if template.Spec.ClusterRoleRef == nil || template.Spec.ClusterRoleRef.Name != mapObject.Meta.GetName() {
+						continue
+					}
+					out = append(out, ctrl.Request{
+						NamespacedName: types.NamespacedName{
+							Name: template.Name,
+						},
+					})
+				}
+				return
+			}),
+		}).
+		// Generated rules are rolled out again, when CustomResourceDefinitions are added or removed.
+		Watches(&source.Kind{Type: &apiextensionsv1.CustomResourceDefinition{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) (out []ctrl.Request) {
+				templates := &corev1alpha1.OrganizationRoleTemplateList{}
+				if err := r.Client.List(context.Background(), templates); err != nil {
+					// This will makes the manager crashes, and it will restart and reconcile all objects again.
+					panic(fmt.Errorf("listting OrganizationRoleTemplate: %w", err))
+				}
+				for _, template := range templates.Items {
+					if len(template.Spec.CRDRules) == 0 {
+						continue
+					}
+					out = append(out, ctrl.Request{
+						NamespacedName: types.NamespacedName{
+							Name: template.Name,
+						},
+					})
+				}
+				return
+			}),
+		}).
 		Complete(r)
 }
 
@@ -193,101 +303,24 @@ func (r *OrganizationRoleTemplateReconciler) handleDeletion(ctx context.Context,
 	return nil
 }
 
-func (r *OrganizationRoleTemplateReconciler) reconcileRBACForOrganization(ctx context.Context, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate, organization *storagev1alpha1.Organization) error {
-	// Reconcile Role.
-	role := &rbacv1.Role{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      organizationRoleTemplate.Name,
-			Namespace: organization.Status.Namespace.Name,
-		},
-		Rules: organizationRoleTemplate.Spec.Rules,
-	}
-	if err := r.reconcileRole(ctx, role, organizationRoleTemplate); err != nil {
-		return err
-	}
-
-	// Reconcile RoleBindings.
-	if organizationRoleTemplate.HasBinding(corev1alpha1.BindToOwners) {
-		roleBinding := &rbacv1.RoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      organizationRoleTemplate.Name,
-				Namespace: organization.Status.Namespace.Name,
-			},
-			Subjects: organization.Spec.Owners,
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: rbacv1.GroupName,
-				Kind:     "Role",
-				Name:     role.Name,
-			},
-		}
-		if err := r.reconcileRoleBinding(ctx, roleBinding, organizationRoleTemplate); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (r *OrganizationRoleTemplateReconciler) reconcileRBACForProject(ctx context.Context, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate, organization *storagev1alpha1.Organization, project *storagev1alpha1.Project) error {
-	// Reconcile Role.
-	role := &rbacv1.Role{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      organizationRoleTemplate.Name,
-			Namespace: project.Status.Namespace.Name,
-		},
-		Rules: organizationRoleTemplate.Spec.Rules,
+// setOrganizationRoleTemplateIncludeCycle sets the IncludeCycle condition for the cycle, which is nil, if there is none.
+// It returns whether the condition changed.
+func setOrganizationRoleTemplateIncludeCycle(organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate, cycle *templates.IncludeCycleError) bool {
+	condition := corev1alpha1.OrganizationRoleTemplateCondition{
+		Type:    corev1alpha1.OrganizationRoleTemplateIncludeCycle,
+		Status:  corev1alpha1.ConditionFalse,
+		Reason:  "NoCycle",
+		Message: "Included templates don't include each other.",
 	}
-	if err := r.reconcileRole(ctx, role, organizationRoleTemplate); err != nil {
-		return err
+	if cycle != nil {
+		condition.Status = corev1alpha1.ConditionTrue
+		condition.Reason = corev1alpha1.OrganizationRoleTemplateIncludeCycleReason
+		condition.Message = cycle.Error()
 	}
-
-	// Reconcile RoleBindings.
-	if organizationRoleTemplate.HasBinding(corev1alpha1.BindToOwners) {
-		roleBinding := &rbacv1.RoleBinding{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      organizationRoleTemplate.Name,
-				Namespace: project.Status.Namespace.Name,
-			},
-			// Here we are creating RoleBindings for Organization Owner, not Project Owner, since OrganizationRoleTemplate is used to config permissions of Organization Owners.
-			Subjects: organization.Spec.Owners,
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: rbacv1.GroupName,
-				Kind:     "Role",
-				Name:     role.Name,
-			},
-		}
-		if err := r.reconcileRoleBinding(ctx, roleBinding, organizationRoleTemplate); err != nil {
-			return err
-		}
+	if current, _ := organizationRoleTemplate.Status.GetCondition(corev1alpha1.OrganizationRoleTemplateIncludeCycle); current.Status == condition.Status &&
+		current.Reason == condition.Reason && current.Message == condition.Message {
+		return false
 	}
-	return nil
-}
-
-func (r *OrganizationRoleTemplateReconciler) reconcileRole(ctx context.Context, role *rbacv1.Role, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate) error {
-	desiredRole := role.DeepCopy()
-	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
-		if err := controllerutil.SetControllerReference(
-			organizationRoleTemplate, role, r.Scheme); err != nil {
-			return fmt.Errorf("set controller reference for Role: %w", err)
-		}
-		role.Rules = desiredRole.Rules
-		return nil
-	}); err != nil {
-		return fmt.Errorf("creating or updating Role: %w", err)
-	}
-	return nil
-}
-
-func (r *OrganizationRoleTemplateReconciler) reconcileRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate) error {
-	desiredRoleBinding := roleBinding.DeepCopy()
-	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, roleBinding, func() error {
-		if err := controllerutil.SetControllerReference(
-			organizationRoleTemplate, roleBinding, r.Scheme); err != nil {
-			return fmt.Errorf("set controller reference for RoleBinding: %w", err)
-		}
-		roleBinding.Subjects = desiredRoleBinding.Subjects
-		return nil
-	}); err != nil {
-		return fmt.Errorf("creating or updating RoleBinding: %w", err)
-	}
-	return nil
+	organizationRoleTemplate.Status.SetCondition(condition)
+	return true
 }
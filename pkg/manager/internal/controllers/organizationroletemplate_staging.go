@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8c.io/utils/pkg/owner"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+const (
+	// revisionLength is the number of hash characters of role template revisions.
+	revisionLength = 10
+	// minBatchInterval limits how often batches are rolled out, if the RolloutStrategy has no BatchInterval.
+	minBatchInterval = time.Second
+)
+
+// organizationRoleTemplateRevision returns the revision of the OrganizationRoleTemplate spec.
+// Metadata and the rollout strategy don't change Roles and RoleBindings, so they are not part of the revision.
+func organizationRoleTemplateRevision(organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate) (string, error) {
+	spec := organizationRoleTemplate.Spec.DeepCopy()
+	spec.Metadata = nil
+	spec.RolloutStrategy = nil
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("marshalling spec: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])[:revisionLength], nil
+}
+
+// stageRollout rolls out the OrganizationRoleTemplate to the Organizations that the staged rollout reached already
+// and to the next batch of outdated Organizations. Targets of the other outdated Organizations are marked as pending.
+// It returns the progress of the staged rollout and when to continue with the next batch.
+func (r *OrganizationRoleTemplateReconciler) stageRollout(
+	ctx context.Context,
+	organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate,
+	revision string,
+	organizations []storagev1alpha1.Organization,
+	targets []roleTemplateTarget,
+	rollout *roleTemplateRollout,
+) (*corev1alpha1.OrganizationRoleTemplateStagedRollout, time.Duration, error) {
+	strategy := organizationRoleTemplate.Spec.RolloutStrategy
+	if strategy == nil {
+		return nil, 0, nil
+	}
+
+	outdatedOrganizations, err := r.outdatedOrganizations(ctx, organizationRoleTemplate, revision, targets)
+	if err != nil {
+		return nil, 0, err
+	}
+	status := &corev1alpha1.OrganizationRoleTemplateStagedRollout{
+		Revision:      revision,
+		Phase:         corev1alpha1.StagedRolloutProgressing,
+		Organizations: len(organizations),
+	}
+	// Organizations that failed in an earlier batch are part of the rollout already and retried first.
+	retried := map[string]bool{}
+	if previous := organizationRoleTemplate.Status.StagedRollout; previous != nil && previous.Revision == revision {
+		status.LastBatchTime = previous.LastBatchTime
+		for _, organization := range previous.FailedOrganizations {
+			if outdatedOrganizations[organization] {
+				retried[organization] = true
+			}
+		}
+	}
+	for i := range targets {
+		if outdatedOrganizations[targets[i].Organization] && !retried[targets[i].Organization] {
+			targets[i].pending = true
+		}
+	}
+	// Failures of the targets the rollout reached are known, before the next batch is rolled out.
+	rollout.apply(ctx, targets)
+
+	canary := labels.Nothing()
+	if strategy.Canary != nil {
+		var err error
+		if canary, err = metav1.LabelSelectorAsSelector(strategy.Canary); err != nil {
+			return nil, 0, fmt.Errorf("parsing canary selector: %w", err)
+		}
+	}
+	var outdatedCanaries, outdated []string
+	for _, organization := range organizations {
+		if !outdatedOrganizations[organization.Name] || retried[organization.Name] {
+			continue
+		}
+		if canary.Matches(labels.Set(organization.Labels)) {
+			outdatedCanaries = append(outdatedCanaries, organization.Name)
+		} else {
+			outdated = append(outdated, organization.Name)
+		}
+	}
+	sort.Strings(outdatedCanaries)
+	sort.Strings(outdated)
+
+	// Canaries are updated first, the other Organizations follow when all canaries are updated.
+	candidates := outdatedCanaries
+	if len(candidates) == 0 {
+		candidates = outdated
+	}
+	var requeueAfter time.Duration
+	inBatch := map[string]bool{}
+	switch {
+	case len(candidates) == 0 && len(retried) == 0:
+		status.Phase = corev1alpha1.StagedRolloutComplete
+	case strategy.Paused:
+		status.Phase = corev1alpha1.StagedRolloutPaused
+	case unavailableTargets(targets) > strategy.MaxUnavailable:
+		// Failed targets are retried with backoff, the rollout continues once they recovered.
+		status.Phase = corev1alpha1.StagedRolloutHalted
+	case len(candidates) == 0:
+		// Only failed Organizations are left, they are retried with backoff.
+	case status.LastBatchTime != nil && time.Since(status.LastBatchTime.Time) < strategy.BatchInterval.Duration:
+		requeueAfter = strategy.BatchInterval.Duration - time.Since(status.LastBatchTime.Time)
+	default:
+		batch := candidates
+		if strategy.BatchSize > 0 && len(batch) > strategy.BatchSize {
+			batch = batch[:strategy.BatchSize]
+		}
+		for _, organization := range batch {
+			inBatch[organization] = true
+		}
+		for i := range targets {
+			if inBatch[targets[i].Organization] {
+				targets[i].pending = false
+				targets[i].applied = false
+				targets[i].err = nil
+			}
+		}
+		rollout.apply(ctx, targets)
+
+		now := metav1.Now()
+		status.LastBatchTime = &now
+		requeueAfter = strategy.BatchInterval.Duration
+		if unavailableTargets(targets) > strategy.MaxUnavailable {
+			status.Phase = corev1alpha1.StagedRolloutHalted
+		}
+	}
+	if status.Phase == corev1alpha1.StagedRolloutProgressing && requeueAfter < minBatchInterval {
+		requeueAfter = minBatchInterval
+	}
+
+	// Outdated Organizations that the rollout reached, but failed to update, are retried by the next reconcile.
+	failed := map[string]bool{}
+	for _, target := range targets {
+		if outdatedOrganizations[target.Organization] && !target.pending && target.err != nil && !failed[target.Organization] {
+			failed[target.Organization] = true
+			status.FailedOrganizations = append(status.FailedOrganizations, target.Organization)
+		}
+	}
+	sort.Strings(status.FailedOrganizations)
+	for _, organization := range organizations {
+		if !outdatedOrganizations[organization.Name] || (retried[organization.Name] || inBatch[organization.Name]) && !failed[organization.Name] {
+			status.UpdatedOrganizations++
+		}
+	}
+	return status, requeueAfter, nil
+}
+
+// outdatedOrganizations returns the Organizations with targets, that don't have a Role of the current revision.
+// This includes Organizations the OrganizationRoleTemplate was never rolled out to, so new targets are staged as well.
+// Targets that fail to render have no Role to roll out, they only count, if they have Roles of another revision.
+func (r *OrganizationRoleTemplateReconciler) outdatedOrganizations(
+	ctx context.Context,
+	organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate,
+	revision string,
+	targets []roleTemplateTarget,
+) (map[string]bool, error) {
+	roles := &rbacv1.RoleList{}
+	if err := r.List(ctx, roles, owner.OwnedBy(organizationRoleTemplate, r.Scheme)); err != nil {
+		return nil, fmt.Errorf("listing Roles: %w", err)
+	}
+	outdatedNamespaces := map[string]bool{}
+	currentNamespaces := map[string]bool{}
+	for _, role := range roles.Items {
+		if role.Annotations[corev1alpha1.RoleTemplateRevisionAnnotation] == revision {
+			currentNamespaces[role.Namespace] = true
+		} else {
+			outdatedNamespaces[role.Namespace] = true
+		}
+	}
+	outdatedOrganizations := map[string]bool{}
+	for _, target := range targets {
+		if outdatedNamespaces[target.Namespace] || target.Err == nil && !currentNamespaces[target.Namespace] {
+			outdatedOrganizations[target.Organization] = true
+		}
+	}
+	return outdatedOrganizations, nil
+}
+
+// unavailableTargets returns the number of failed targets, that the staged rollout reached.
+// Pending targets only had their RoleBinding reconciled, their failures don't block the rollout.
+func unavailableTargets(targets []roleTemplateTarget) int {
+	var unavailable int
+	for _, target := range targets {
+		if !target.pending && target.err != nil {
+			unavailable++
+		}
+	}
+	return unavailable
+}
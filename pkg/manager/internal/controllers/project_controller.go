@@ -33,7 +33,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/naming"
 )
 
 const (
@@ -45,6 +47,8 @@ type ProjectReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+
+	NamespacePolicy *naming.NamespacePolicy
 }
 
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=projects,verbs=get;list;watch;update
@@ -73,9 +77,13 @@ func (r *ProjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		}
 	}
 
-	if err := r.reconcileNamespace(ctx, log, project); err != nil {
+	conflict, err := r.reconcileNamespace(ctx, log, project)
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("reconciling namespace: %w", err)
 	}
+	if conflict {
+		return ctrl.Result{RequeueAfter: namespaceConflictRequeueInterval}, nil
+	}
 
 	if err := r.reconcileMembers(ctx, project); err != nil {
 		return ctrl.Result{}, fmt.Errorf("reconciling members: %w", err)
@@ -96,36 +104,105 @@ func (r *ProjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
-func (r *ProjectReconciler) reconcileNamespace(ctx context.Context, log logr.Logger, project *storagev1alpha1.Project) error {
+func (r *ProjectReconciler) reconcileNamespace(ctx context.Context, log logr.Logger, project *storagev1alpha1.Project) (conflict bool, err error) {
+	nsName, err := r.namespaceName(project)
+	if err != nil {
+		return false, err
+	}
 	ns := &corev1.Namespace{}
-	ns.Name = fmt.Sprintf("%s-%s", project.Namespace, project.Name)
+	ns.Name = nsName
+	ns.Labels = map[string]string{
+		corev1alpha1.ManagedLabel: "true",
+	}
 
-	if _, err := owner.ReconcileOwnedObjects(ctx, r.Client, log, r.Scheme, project, []runtime.Object{ns}, &corev1.Namespace{}, nil); err != nil {
-		return fmt.Errorf("cannot reconcile namespace: %w", err)
+	conflict, err = checkNamespaceConflict(ctx, r.Client, r.Scheme, project, ns.Name)
+	if err != nil {
+		return false, err
+	}
+	if conflict {
+		log.Info("namespace already exists and is not owned by this Project", "namespace", ns.Name)
+		project.Status.ObservedGeneration = project.Generation
+		project.Status.SetCondition(storagev1alpha1.ProjectCondition{
+			Type:    storagev1alpha1.ProjectNamespaceConflict,
+			Status:  storagev1alpha1.ConditionTrue,
+			Reason:  storagev1alpha1.ProjectNamespaceConflictReason,
+			Message: fmt.Sprintf("Namespace %s already exists and was not created for this Project.", ns.Name),
+		})
+		project.Status.SetCondition(storagev1alpha1.ProjectCondition{
+			Type:    storagev1alpha1.ProjectReady,
+			Status:  storagev1alpha1.ConditionFalse,
+			Reason:  storagev1alpha1.ProjectNamespaceConflictReason,
+			Message: fmt.Sprintf("Namespace %s is taken.", ns.Name),
+		})
+		if err := r.Status().Update(ctx, project); err != nil {
+			return false, fmt.Errorf("updating Project status: %w", err)
+		}
+		return true, nil
 	}
 
 	if project.Status.Namespace == nil {
+		// Record the name before creating the Namespace, so it is never renamed later on.
 		project.Status.Namespace = &storagev1alpha1.ObjectReference{
 			Name: ns.Name,
 		}
 		if err := r.Status().Update(ctx, project); err != nil {
-			return fmt.Errorf("updating NamespaceName: %w", err)
+			return false, fmt.Errorf("updating NamespaceName: %w", err)
 		}
 	}
 
-	return nil
+	if _, err := owner.ReconcileOwnedObjects(ctx, r.Client, log, r.Scheme, project, []runtime.Object{ns}, &corev1.Namespace{}, updateNamespaceLabels); err != nil {
+		return false, fmt.Errorf("cannot reconcile namespace: %w", err)
+	}
+
+	if conflictCondition, _ := project.Status.GetCondition(storagev1alpha1.ProjectNamespaceConflict); conflictCondition.Status == storagev1alpha1.ConditionTrue {
+		project.Status.SetCondition(storagev1alpha1.ProjectCondition{
+			Type:    storagev1alpha1.ProjectNamespaceConflict,
+			Status:  storagev1alpha1.ConditionFalse,
+			Reason:  "NamespaceCreated",
+			Message: fmt.Sprintf("Namespace %s is owned by this Project.", ns.Name),
+		})
+		if err := r.Status().Update(ctx, project); err != nil {
+			return false, fmt.Errorf("updating Project status: %w", err)
+		}
+	}
+
+	return false, nil
+}
+
+// namespaceName returns the Namespace name of the Project. Once recorded in the status, the name is kept,
+// so changes of the NamespacePolicy don't affect existing Projects.
+func (r *ProjectReconciler) namespaceName(project *storagev1alpha1.Project) (string, error) {
+	if project.Status.Namespace != nil {
+		return project.Status.Namespace.Name, nil
+	}
+	name, err := r.NamespacePolicy.ProjectNamespace(project)
+	if err != nil {
+		return "", fmt.Errorf("building namespace name: %w", err)
+	}
+	return name, nil
 }
 
+// reconcileMembers computes the members of the Project from its owners and user created RoleBindings.
+// RoleBindings managed by role templates are ignored, as their subjects are derived from the members themselves.
+// The source of every membership is recorded, so a subject is dropped as soon as the last object granting it is gone.
 func (r *ProjectReconciler) reconcileMembers(ctx context.Context, project *storagev1alpha1.Project) error {
 	rbs := &rbacv1.RoleBindingList{}
 	if err := r.List(ctx, rbs, client.InNamespace(project.Status.Namespace.Name)); err != nil {
 		return fmt.Errorf("list rolebindings: %w", err)
 	}
-	var subjects []rbacv1.Subject
+	members := memberSet{}
+	members.add(storagev1alpha1.MemberSource{Kind: storagev1alpha1.MemberSourceOwner}, project.Spec.Owners...)
 	for _, roleBinding := range rbs.Items {
-		subjects = append(subjects, roleBinding.Subjects...)
+		if isManagedByTemplate(&roleBinding) {
+			continue
+		}
+		members.add(storagev1alpha1.MemberSource{
+			Kind: storagev1alpha1.MemberSourceRoleBinding,
+			Name: roleBinding.Name,
+		}, roleBinding.Subjects...)
 	}
-	project.Status.Members = extractSubjects(subjects)
+	project.Status.Members = members.members()
+	project.Status.MemberProvenance = members.provenance()
 	if err := r.Status().Update(ctx, project); err != nil {
 		return fmt.Errorf("updating members: %w", err)
 	}
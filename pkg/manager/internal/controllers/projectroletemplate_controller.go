@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -25,16 +26,20 @@ import (
 	"k8c.io/utils/pkg/owner"
 	"k8c.io/utils/pkg/util"
 	rbacv1 "k8s.io/api/rbac/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/templates"
 )
 
 const (
@@ -44,19 +49,26 @@ const (
 // ProjectRoleTemplateReconciler reconciles a Project object
 type ProjectRoleTemplateReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=bulward.io,resources=projectroletemplates,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=bulward.io,resources=projectroletemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=bulward.io,resources=organizationroletemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=organizations,verbs=get;list;watch;update
 // +kubebuilder:rbac:groups=storage.bulward.io,resources=projects,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete;bind;escalate
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *ProjectRoleTemplateReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	ctx := context.Background()
+	log := r.Log.WithValues("ProjectRoleTemplate", req.NamespacedName)
 
 	projectRoleTemplate := &corev1alpha1.ProjectRoleTemplate{}
 	if err := r.Get(ctx, req.NamespacedName, projectRoleTemplate); err != nil {
@@ -76,28 +88,74 @@ func (r *ProjectRoleTemplateReconciler) Reconcile(req ctrl.Request) (ctrl.Result
 		}
 	}
 
-	var targets []corev1alpha1.RoleTemplateTarget
-	selectedReadyProjects, err := r.listSelectedReadyProjects(ctx, projectRoleTemplate)
+	desired, err := templates.ProjectRoleTemplateTargets(ctx, r.Client, projectRoleTemplate)
+	var cycle *templates.IncludeCycleError
+	if errors.As(err, &cycle) {
+		// Existing Roles and RoleBindings are kept, until the cycle is resolved by changing one of the included templates.
+		if setProjectRoleTemplateIncludeCycle(projectRoleTemplate, cycle) {
+			if err := r.Status().Update(ctx, projectRoleTemplate); err != nil {
+				return ctrl.Result{}, fmt.Errorf("updating ProjectRoleTemplate status: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("listing selected ready Projects: %w", err)
+		return ctrl.Result{}, fmt.Errorf("computing targets: %w", err)
+	}
+	targets := make([]roleTemplateTarget, 0, len(desired))
+	for _, target := range desired {
+		targets = append(targets, roleTemplateTarget{Target: target})
 	}
 
-	// Reconcile Role/RoleBindings in Project namespaces.
-	for _, project := range selectedReadyProjects {
-		if err := r.reconcileRBACForProject(ctx, projectRoleTemplate, &project); err != nil {
-			return ctrl.Result{}, fmt.Errorf("reconcling Project RBAC: %w", err)
-		}
-		targets = append(targets, corev1alpha1.RoleTemplateTarget{
-			Kind:               project.Kind,
-			APIGroup:           project.GroupVersionKind().Group,
-			Name:               project.Name,
-			ObservedGeneration: project.Status.ObservedGeneration,
-		})
+	// Roll out Role/RoleBindings of every Project on its own, so a single broken namespace doesn't block the others.
+	// Objects in Namespaces of Projects that are no longer selected are pruned.
+	rollout := &roleTemplateRollout{
+		Client:   r.Client,
+		Log:      log,
+		Scheme:   r.Scheme,
+		Recorder: r.Recorder,
+		template: projectRoleTemplate,
+		previous: projectRoleTemplate.Status.Rollout,
 	}
+	rolloutStatus, conflicts, pruneErr := rollout.rollout(ctx, targets)
 
 	var changed bool
-	if !reflect.DeepEqual(targets, projectRoleTemplate.Status.Targets) {
-		projectRoleTemplate.Status.Targets = targets
+	if !reflect.DeepEqual(rolloutStatus, projectRoleTemplate.Status.Rollout) {
+		projectRoleTemplate.Status.Rollout = rolloutStatus
+		changed = true
+	}
+	degraded := corev1alpha1.ProjectRoleTemplateCondition{
+		Type:    corev1alpha1.ProjectRoleTemplateDegraded,
+		Status:  corev1alpha1.ConditionFalse,
+		Reason:  "RolloutComplete",
+		Message: rolloutMessage(rolloutStatus),
+	}
+	if rolloutStatus.Failed > 0 {
+		degraded.Status = corev1alpha1.ConditionTrue
+		degraded.Reason = corev1alpha1.ProjectRoleTemplateRolloutFailedReason
+	}
+	if current, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateDegraded); current.Status != degraded.Status ||
+		current.Reason != degraded.Reason || current.Message != degraded.Message {
+		projectRoleTemplate.Status.SetCondition(degraded)
+		changed = true
+	}
+	// Roles and RoleBindings owned by someone else are never overwritten, the competing owner is reported instead.
+	conflict := corev1alpha1.ProjectRoleTemplateCondition{
+		Type:    corev1alpha1.ProjectRoleTemplateConflict,
+		Status:  corev1alpha1.ConditionFalse,
+		Reason:  "NoConflict",
+		Message: conflictMessage(conflicts),
+	}
+	if len(conflicts) > 0 {
+		conflict.Status = corev1alpha1.ConditionTrue
+		conflict.Reason = corev1alpha1.ProjectRoleTemplateNameConflictReason
+	}
+	if current, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateConflict); current.Status != conflict.Status ||
+		current.Reason != conflict.Reason || current.Message != conflict.Message {
+		projectRoleTemplate.Status.SetCondition(conflict)
+		changed = true
+	}
+	if setProjectRoleTemplateIncludeCycle(projectRoleTemplate, nil) {
 		changed = true
 	}
 	if !projectRoleTemplate.IsReady() {
@@ -117,6 +175,12 @@ func (r *ProjectRoleTemplateReconciler) Reconcile(req ctrl.Request) (ctrl.Result
 			return ctrl.Result{}, fmt.Errorf("updating ProjectRoleTemplate status: %w", err)
 		}
 	}
+	if pruneErr != nil {
+		return ctrl.Result{}, fmt.Errorf("pruning Project RBAC: %w", pruneErr)
+	}
+	if rolloutStatus.Failed > 0 {
+		return ctrl.Result{}, fmt.Errorf("rolling out Project RBAC: %s", rolloutMessage(rolloutStatus))
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -140,9 +204,99 @@ func (r *ProjectRoleTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error
 		}),
 	}
 
+	// Templates are rendered again, when a template they directly or transitively include changes.
+	enqueueIncluders := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) (out []ctrl.Request) {
+			organizationRoleTemplates := &corev1alpha1.OrganizationRoleTemplateList{}
+			if err := r.Client.List(context.Background(), organizationRoleTemplates); err != nil {
+				// This will makes the manager crashes, and it will restart and reconcile all objects again.
+				panic(fmt.Errorf("listting OrganizationRoleTemplate: %w", err))
+			}
+			projectRoleTemplates := &corev1alpha1.ProjectRoleTemplateList{}
+			if err := r.Client.List(context.Background(), projectRoleTemplates); err != nil {
+				// This will makes the manager crashes, and it will restart and reconcile all objects again.
+				panic(fmt.Errorf("listting ProjectRoleTemplate: %w", err))
+			}
+			graph := templates.IncludeGraph{}
+			for i := range organizationRoleTemplates.Items {
+				graph.AddOrganizationRoleTemplate(&organizationRoleTemplates.Items[i])
+			}
+			for i := range projectRoleTemplates.Items {
+				graph.AddProjectRoleTemplate(&projectRoleTemplates.Items[i])
+			}
+
+			key := templates.TemplateKey{Kind: templates.OrganizationRoleTemplateKind, Name: mapObject.Meta.GetName()}
+			if _, ok := mapObject.Object.(*corev1alpha1.ProjectRoleTemplate); ok {
+				key = templates.TemplateKey{Kind: templates.ProjectRoleTemplateKind, Namespace: mapObject.Meta.GetNamespace(), Name: mapObject.Meta.GetName()}
+			}
+			for _, includer := range graph.Includers(key) {
+				if includer.Kind != templates.ProjectRoleTemplateKind {
+					continue
+				}
+				out = append(out, ctrl.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      includer.Name,
+						Namespace: includer.Namespace,
+					},
+				})
+			}
+			return
+		}),
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&corev1alpha1.ProjectRoleTemplate{}).
+		// Status updates are skipped, as the rollout status is written by this controller.
+		For(&corev1alpha1.ProjectRoleTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1alpha1.ProjectRoleTemplate{}}, enqueueIncluders, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &corev1alpha1.OrganizationRoleTemplate{}}, enqueueIncluders, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&source.Kind{Type: &storagev1alpha1.Project{}}, enqueueAllTemplates).
+		// Roles and RoleBindings changed or deleted by others are corrected right away, instead of on the next resync.
+		Watches(&source.Kind{Type: &rbacv1.Role{}}, owner.EnqueueRequestForOwner(&corev1alpha1.ProjectRoleTemplate{}, mgr.GetScheme())).
+		Watches(&source.Kind{Type: &rbacv1.RoleBinding{}}, owner.EnqueueRequestForOwner(&corev1alpha1.ProjectRoleTemplate{}, mgr.GetScheme())).
+		// Copied rules are rolled out again, when the referenced ClusterRole changes.
+		Watches(&source.Kind{Type: &rbacv1.ClusterRole{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) (out []ctrl.Request) {
+				templates := &corev1alpha1.ProjectRoleTemplateList{}
+				if err := r.Client.List(context.Background(), templates); err != nil {
+					// This will makes the manager crashes, and it will restart and reconcile all objects again.
+					panic(fmt.Errorf("listting ProjectRoleTemplate: %w", err))
+				}
+				for _, template := range templates.Items {
+					if template.Spec.ClusterRoleRef == nil || template.Spec.ClusterRoleRef.Name != mapObject.Meta.GetName() {
+						continue
+					}
+					out = append(out, ctrl.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      template.Name,
+							Namespace: template.Namespace,
+						},
+					})
+				}
+				return
+			}),
+		}).
+		// Generated rules are rolled out again, when CustomResourceDefinitions are added or removed.
+		Watches(&source.Kind{Type: &apiextensionsv1.CustomResourceDefinition{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) (out []ctrl.Request) {
+				templates := &corev1alpha1.ProjectRoleTemplateList{}
+				if err := r.Client.List(context.Background(), templates); err != nil {
+					// This will makes the manager crashes, and it will restart and reconcile all objects again.
+					panic(fmt.Errorf("listting ProjectRoleTemplate: %w", err))
+				}
+				for _, template := range templates.Items {
+					if len(template.Spec.CRDRules) == 0 {
+						continue
+					}
+					out = append(out, ctrl.Request{
+						NamespacedName: types.NamespacedName{
+							Name:      template.Name,
+							Namespace: template.Namespace,
+						},
+					})
+				}
+				return
+			}),
+		}).
 		Complete(r)
 }
 
@@ -179,92 +333,24 @@ func (r *ProjectRoleTemplateReconciler) handleDeletion(ctx context.Context, proj
 	return nil
 }
 
-func (r *ProjectRoleTemplateReconciler) reconcileRBACForProject(ctx context.Context, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate, project *storagev1alpha1.Project) error {
-	// Reconcile Role.
-	role := &rbacv1.Role{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      projectRoleTemplate.Name,
-			Namespace: project.Status.Namespace.Name,
-		},
-		Rules: projectRoleTemplate.Spec.Rules,
-	}
-	if err := r.reconcileRole(ctx, role, projectRoleTemplate); err != nil {
-		return err
-	}
-
-	// Reconcile RoleBindings.
-	var subjects []rbacv1.Subject
-	if projectRoleTemplate.HasBinding(corev1alpha1.BindToEveryone) {
-		// This is needed, because it can be the case that Organization Owner has not created any RoleBindings for Project
-		// Owner, so Project owner will not present in the project.Status.Member.
-		subjects = append(subjects, project.Spec.Owners...)
-		subjects = append(subjects, project.Status.Members...)
-	} else if projectRoleTemplate.HasBinding(corev1alpha1.BindToOwners) {
-		subjects = append(subjects, project.Spec.Owners...)
-	}
-	roleBinding := &rbacv1.RoleBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      projectRoleTemplate.Name,
-			Namespace: project.Status.Namespace.Name,
-		},
-		Subjects: extractSubjects(subjects),
-		RoleRef: rbacv1.RoleRef{
-			APIGroup: rbacv1.GroupName,
-			Kind:     "Role",
-			Name:     role.Name,
-		},
-	}
-	if err := r.reconcileRoleBinding(ctx, roleBinding, projectRoleTemplate); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (r *ProjectRoleTemplateReconciler) reconcileRole(ctx context.Context, role *rbacv1.Role, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) error {
-	if _, err := owner.ReconcileOwnedObjects(ctx, r.Client, r.Log, r.Scheme,
-		projectRoleTemplate,
-		[]runtime.Object{role}, &rbacv1.Role{},
-		func(actual, desired runtime.Object) error {
-			actualRule := actual.(*rbacv1.Role)
-			desiredRole := desired.(*rbacv1.Role)
-			actualRule.Rules = desiredRole.Rules
-			return nil
-		}); err != nil {
-		return fmt.Errorf("cannot reconcile Role: %w", err)
-	}
-	return nil
-}
-
-func (r *ProjectRoleTemplateReconciler) reconcileRoleBinding(ctx context.Context, roleBinding *rbacv1.RoleBinding, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) error {
-	if _, err := owner.ReconcileOwnedObjects(ctx, r.Client, r.Log, r.Scheme,
-		projectRoleTemplate,
-		[]runtime.Object{roleBinding}, &rbacv1.RoleBinding{},
-		func(actual, desired runtime.Object) error {
-			actualRuleBinding := actual.(*rbacv1.RoleBinding)
-			desiredRoleBinding := desired.(*rbacv1.RoleBinding)
-			actualRuleBinding.RoleRef = desiredRoleBinding.RoleRef
-			actualRuleBinding.Subjects = desiredRoleBinding.Subjects
-			return nil
-		}); err != nil {
-		return fmt.Errorf("cannot reconcile RoleBinding: %w", err)
+// setProjectRoleTemplateIncludeCycle sets the IncludeCycle condition for the cycle, which is nil, if there is none.
+// It returns whether the condition changed.
+func setProjectRoleTemplateIncludeCycle(projectRoleTemplate *corev1alpha1.ProjectRoleTemplate, cycle *templates.IncludeCycleError) bool {
+	condition := corev1alpha1.ProjectRoleTemplateCondition{
+		Type:    corev1alpha1.ProjectRoleTemplateIncludeCycle,
+		Status:  corev1alpha1.ConditionFalse,
+		Reason:  "NoCycle",
+		Message: "Included templates don't include each other.",
 	}
-	return nil
-}
-
-func (r *ProjectRoleTemplateReconciler) listSelectedReadyProjects(ctx context.Context, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) ([]storagev1alpha1.Project, error) {
-	projectSelector, err := metav1.LabelSelectorAsSelector(projectRoleTemplate.Spec.ProjectSelector)
-	if err != nil {
-		return nil, fmt.Errorf("parsing Project selector: %w", err)
-	}
-	projects := &storagev1alpha1.ProjectList{}
-	if err := r.List(ctx, projects, client.InNamespace(projectRoleTemplate.Namespace), client.MatchingLabelsSelector{Selector: projectSelector}); err != nil {
-		return nil, fmt.Errorf("listing Project: %w", err)
+	if cycle != nil {
+		condition.Status = corev1alpha1.ConditionTrue
+		condition.Reason = corev1alpha1.ProjectRoleTemplateIncludeCycleReason
+		condition.Message = cycle.Error()
 	}
-	var readyProjects []storagev1alpha1.Project
-	for _, project := range projects.Items {
-		if project.IsReady() {
-			readyProjects = append(readyProjects, project)
-		}
+	if current, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateIncludeCycle); current.Status == condition.Status &&
+		current.Reason == condition.Reason && current.Message == condition.Message {
+		return false
 	}
-	return readyProjects, nil
+	projectRoleTemplate.Status.SetCondition(condition)
+	return true
 }
@@ -0,0 +1,366 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8c.io/utils/pkg/owner"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+const (
+	// RulesRevokedReason is the Event reason used when rules exceeding the role templates are removed from a Role.
+	RulesRevokedReason = "RulesRevoked"
+	// RulesExceedTemplatesReason is the Event reason used in dry-run mode, when a Role grants more than the role templates.
+	RulesExceedTemplatesReason = "RulesExceedTemplates"
+)
+
+// namespaceOwnerTypes are the owner types of Namespaces that are managed by Bulward.
+var namespaceOwnerTypes = map[string]bool{
+	storagev1alpha1.SchemeGroupVersion.WithKind("Organization").GroupKind().String(): true,
+	storagev1alpha1.SchemeGroupVersion.WithKind("Project").GroupKind().String():      true,
+}
+
+// roleRevocation is recorded in the corev1alpha1.RoleRevocationAnnotation of user created Roles.
+type roleRevocation struct {
+	// DryRun is true, if the Rules were only reported and are still part of the Role.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Rules are the rules of the Role that are not granted by any role template.
+	Rules []rbacv1.PolicyRule `json:"rules"`
+}
+
+// RoleRevocationReconciler trims user created Roles in Bulward managed namespaces,
+// so they never grant more than the role templates in the same namespace.
+// Without it, permissions removed from a template are kept by Roles that copied them.
+type RoleRevocationReconciler struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Enabled revokes rules in all managed namespaces, that don't set the corev1alpha1.RoleRevocationModeLabel.
+	Enabled bool
+	// DryRun only reports exceeding rules via Events and annotations, without changing the Roles.
+	DryRun bool
+}
+
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (r *RoleRevocationReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.Background()
+	log := r.Log.WithValues("Role", req.NamespacedName)
+
+	role := &rbacv1.Role{}
+	if err := r.Get(ctx, req.NamespacedName, role); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !role.DeletionTimestamp.IsZero() || isManagedByTemplate(role) {
+		return ctrl.Result{}, nil
+	}
+
+	mode, err := r.revocationMode(ctx, role.Namespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("checking Namespace: %w", err)
+	}
+	if mode == corev1alpha1.RoleRevocationDisabled {
+		return ctrl.Result{}, nil
+	}
+	dryRun := mode == corev1alpha1.RoleRevocationDryRun
+
+	granted, err := r.templateRules(ctx, role.Namespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("collecting template rules: %w", err)
+	}
+	kept, revoked := splitRules(role.Rules, granted)
+
+	if len(revoked) == 0 {
+		// Clear stale dry-run reports, after the templates were extended to cover the Role again.
+		if value, ok := role.Annotations[corev1alpha1.RoleRevocationAnnotation]; ok && (dryRun || isDryRunReport(value)) {
+			delete(role.Annotations, corev1alpha1.RoleRevocationAnnotation)
+			if err := r.Update(ctx, role); err != nil {
+				return ctrl.Result{}, fmt.Errorf("updating Role: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	annotation, err := json.Marshal(roleRevocation{DryRun: dryRun, Rules: revoked})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("marshalling revocation: %w", err)
+	}
+	if dryRun && role.Annotations[corev1alpha1.RoleRevocationAnnotation] == string(annotation) {
+		// Already reported.
+		return ctrl.Result{}, nil
+	}
+
+	if role.Annotations == nil {
+		role.Annotations = map[string]string{}
+	}
+	role.Annotations[corev1alpha1.RoleRevocationAnnotation] = string(annotation)
+	if !dryRun {
+		role.Rules = kept
+	}
+	if err := r.Update(ctx, role); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating Role: %w", err)
+	}
+
+	if dryRun {
+		log.Info("rules exceed role templates", "rules", describeRules(revoked))
+		r.Recorder.Eventf(role, corev1.EventTypeWarning, RulesExceedTemplatesReason,
+			"Rules are not granted by any role template and would be revoked: %s", describeRules(revoked))
+		return ctrl.Result{}, nil
+	}
+	log.Info("revoked rules", "rules", describeRules(revoked))
+	r.Recorder.Eventf(role, corev1.EventTypeWarning, RulesRevokedReason,
+		"Revoked rules that are not granted by any role template: %s", describeRules(revoked))
+	return ctrl.Result{}, nil
+}
+
+// isDryRunReport checks if the RoleRevocationAnnotation value only reports rules, which were never revoked.
+func isDryRunReport(value string) bool {
+	revocation := roleRevocation{}
+	return json.Unmarshal([]byte(value), &revocation) == nil && revocation.DryRun
+}
+
+// revocationMode returns the role revocation mode of the Namespace.
+// Namespaces that were not created for an Organization or Project are never touched,
+// the others use the mode of their corev1alpha1.RoleRevocationModeLabel or the manager flags.
+func (r *RoleRevocationReconciler) revocationMode(ctx context.Context, name string) (corev1alpha1.RoleRevocationMode, error) {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return corev1alpha1.RoleRevocationDisabled, client.IgnoreNotFound(err)
+	}
+	if !namespaceOwnerTypes[ns.Labels[owner.OwnerTypeLabel]] {
+		return corev1alpha1.RoleRevocationDisabled, nil
+	}
+	switch mode := corev1alpha1.RoleRevocationMode(ns.Labels[corev1alpha1.RoleRevocationModeLabel]); mode {
+	case corev1alpha1.RoleRevocationEnforce, corev1alpha1.RoleRevocationDryRun, corev1alpha1.RoleRevocationDisabled:
+		return mode, nil
+	}
+	switch {
+	case !r.Enabled:
+		return corev1alpha1.RoleRevocationDisabled, nil
+	case r.DryRun:
+		return corev1alpha1.RoleRevocationDryRun, nil
+	}
+	return corev1alpha1.RoleRevocationEnforce, nil
+}
+
+// templateRules returns the union of the rules that role templates grant in the namespace.
+func (r *RoleRevocationReconciler) templateRules(ctx context.Context, namespace string) ([]rbacv1.PolicyRule, error) {
+	roles := &rbacv1.RoleList{}
+	if err := r.List(ctx, roles, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing Roles: %w", err)
+	}
+	var rules []rbacv1.PolicyRule
+	for _, role := range roles.Items {
+		if isManagedByTemplate(&role) {
+			rules = append(rules, role.Rules...)
+		}
+	}
+	return rules, nil
+}
+
+func (r *RoleRevocationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("rolerevocation").
+		For(&rbacv1.Role{}).
+		// Changes of template Roles affect all user created Roles in the same namespace.
+		Watches(&source.Kind{Type: &rbacv1.Role{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) []ctrl.Request {
+				if !isManagedByTemplate(mapObject.Meta) {
+					return nil
+				}
+				return r.userRoleRequests(mapObject.Meta.GetNamespace())
+			}),
+		}).
+		// Changes of the RoleRevocationModeLabel affect all user created Roles in the Namespace.
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(mapObject handler.MapObject) []ctrl.Request {
+				if !namespaceOwnerTypes[mapObject.Meta.GetLabels()[owner.OwnerTypeLabel]] {
+					return nil
+				}
+				return r.userRoleRequests(mapObject.Meta.GetName())
+			}),
+		}).
+		Complete(r)
+}
+
+// userRoleRequests returns requests for all Roles in the namespace, that are not managed by role templates.
+func (r *RoleRevocationReconciler) userRoleRequests(namespace string) (out []ctrl.Request) {
+	roles := &rbacv1.RoleList{}
+	if err := r.Client.List(context.Background(), roles, client.InNamespace(namespace)); err != nil {
+		// This will makes the manager crashes, and it will restart and reconcile all objects again.
+		panic(fmt.Errorf("listing Roles: %w", err))
+	}
+	for _, role := range roles.Items {
+		if isManagedByTemplate(&role) {
+			continue
+		}
+		out = append(out, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      role.Name,
+				Namespace: role.Namespace,
+			},
+		})
+	}
+	return
+}
+
+// splitRules splits the rules into the parts that are covered by the granted rules and the parts that are not.
+// Rules that are not covered as a whole are broken down per API group, resource and resource name.
+func splitRules(rules, granted []rbacv1.PolicyRule) (kept, revoked []rbacv1.PolicyRule) {
+	for _, rule := range rules {
+		if len(rule.NonResourceURLs) > 0 {
+			// Roles can't grant access to non-resource URLs, so there is nothing to revoke.
+			kept = append(kept, rule)
+			continue
+		}
+		if ruleCovered(rule, granted) {
+			kept = append(kept, rule)
+			continue
+		}
+		resourceNames := rule.ResourceNames
+		if len(resourceNames) == 0 {
+			resourceNames = []string{""}
+		}
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, name := range resourceNames {
+					var keptVerbs, revokedVerbs []string
+					for _, verb := range rule.Verbs {
+						if permissionCovered(group, resource, verb, name, granted) {
+							keptVerbs = append(keptVerbs, verb)
+						} else {
+							revokedVerbs = append(revokedVerbs, verb)
+						}
+					}
+					if len(keptVerbs) > 0 {
+						kept = append(kept, singlePolicyRule(group, resource, name, keptVerbs))
+					}
+					if len(revokedVerbs) > 0 {
+						revoked = append(revoked, singlePolicyRule(group, resource, name, revokedVerbs))
+					}
+				}
+			}
+		}
+	}
+	return kept, revoked
+}
+
+// ruleCovered checks if every permission of the rule is covered by the granted rules.
+func ruleCovered(rule rbacv1.PolicyRule, granted []rbacv1.PolicyRule) bool {
+	resourceNames := rule.ResourceNames
+	if len(resourceNames) == 0 {
+		resourceNames = []string{""}
+	}
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			for _, verb := range rule.Verbs {
+				for _, name := range resourceNames {
+					if !permissionCovered(group, resource, verb, name, granted) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// permissionCovered checks if a single permission is granted by any of the rules.
+// An empty name stands for all objects of the resource.
+func permissionCovered(group, resource, verb, name string, granted []rbacv1.PolicyRule) bool {
+	for _, rule := range granted {
+		if matches(rule.APIGroups, group) &&
+			resourceMatches(rule.Resources, resource) &&
+			matches(rule.Verbs, verb) &&
+			(len(rule.ResourceNames) == 0 || name != "" && contains(rule.ResourceNames, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks if the value is part of the values or the values contain the wildcard.
+func matches(values []string, value string) bool {
+	return contains(values, rbacv1.APIGroupAll) || contains(values, value)
+}
+
+// resourceMatches works like matches, but also supports wildcards for subresources like "*/scale".
+func resourceMatches(resources []string, resource string) bool {
+	if matches(resources, resource) {
+		return true
+	}
+	parts := strings.SplitN(resource, "/", 2)
+	return len(parts) == 2 && contains(resources, rbacv1.ResourceAll+"/"+parts[1])
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func singlePolicyRule(group, resource, name string, verbs []string) rbacv1.PolicyRule {
+	rule := rbacv1.PolicyRule{
+		APIGroups: []string{group},
+		Resources: []string{resource},
+		Verbs:     verbs,
+	}
+	if name != "" {
+		rule.ResourceNames = []string{name}
+	}
+	return rule
+}
+
+// describeRules returns a short human readable description of the rules for Events and logs.
+func describeRules(rules []rbacv1.PolicyRule) string {
+	descriptions := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		description := strings.Join(rule.Verbs, ",") + " " + strings.Join(rule.Resources, ",")
+		if groups := strings.Join(rule.APIGroups, ","); groups != "" {
+			description += "." + groups
+		}
+		if len(rule.ResourceNames) > 0 {
+			description += " " + strings.Join(rule.ResourceNames, ",")
+		}
+		descriptions = append(descriptions, description)
+	}
+	return strings.Join(descriptions, ", ")
+}
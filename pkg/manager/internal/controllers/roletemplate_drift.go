@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// DriftCorrectedReason is the Event reason used when a Role or RoleBinding of a role template was changed by others
+// and the changes are overwritten.
+const DriftCorrectedReason = "DriftCorrected"
+
+// rbacDriftCorrectionsTotal counts the Roles and RoleBindings of role templates, that were changed by others and corrected.
+var rbacDriftCorrectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "bulward_rbac_drift_corrections_total",
+	Help: "Number of corrected Roles and RoleBindings of role templates, whose rules or subjects were changed out-of-band.",
+}, []string{"template_kind", "kind"})
+
+func init() {
+	metrics.Registry.MustRegister(rbacDriftCorrectionsTotal)
+}
+
+// setLastAppliedRBAC records the applied rules or subjects on the object.
+func setLastAppliedRBAC(obj metav1.Object, applied interface{}) error {
+	data, err := json.Marshal(applied)
+	if err != nil {
+		return fmt.Errorf("marshalling: %w", err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[corev1alpha1.LastAppliedRBACAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// hasDrifted checks if the rules or subjects of the object were changed since they were last applied.
+// The last applied rules or subjects are decoded into lastApplied, so the drift can be described against them
+// instead of against the desired state, that may contain changes of the role template itself.
+// Objects without recorded state were not applied with drift detection yet and never drifted.
+func hasDrifted(obj metav1.Object, current, lastApplied interface{}) (bool, error) {
+	recorded, ok := obj.GetAnnotations()[corev1alpha1.LastAppliedRBACAnnotation]
+	if !ok {
+		return false, nil
+	}
+	data, err := json.Marshal(current)
+	if err != nil {
+		return false, fmt.Errorf("marshalling: %w", err)
+	}
+	if string(data) == recorded {
+		return false, nil
+	}
+	if err := json.Unmarshal([]byte(recorded), lastApplied); err != nil {
+		return false, fmt.Errorf("unmarshalling last applied: %w", err)
+	}
+	return true, nil
+}
+
+// defaultSubjects returns the subjects with the API group defaulted like by the API server,
+// so the recorded subjects match the stored ones.
+func defaultSubjects(subjects []rbacv1.Subject) []rbacv1.Subject {
+	defaulted := make([]rbacv1.Subject, 0, len(subjects))
+	for _, subject := range subjects {
+		if subject.APIGroup == "" && (subject.Kind == rbacv1.UserKind || subject.Kind == rbacv1.GroupKind) {
+			subject.APIGroup = rbacv1.GroupName
+		}
+		defaulted = append(defaulted, subject)
+	}
+	return defaulted
+}
+
+// describeRuleDrift summarizes the difference of the current rules to the last applied ones, e.g. "added rules get secrets".
+func describeRuleDrift(current, lastApplied []rbacv1.PolicyRule) string {
+	var added, removed []rbacv1.PolicyRule
+	for _, rule := range current {
+		if !containsRule(lastApplied, rule) {
+			added = append(added, rule)
+		}
+	}
+	for _, rule := range lastApplied {
+		if !containsRule(current, rule) {
+			removed = append(removed, rule)
+		}
+	}
+	return describeDrift("rules", describeRules(added), describeRules(removed))
+}
+
+func containsRule(rules []rbacv1.PolicyRule, rule rbacv1.PolicyRule) bool {
+	for _, r := range rules {
+		if reflect.DeepEqual(r, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeSubjectDrift summarizes the difference of the current subjects to the last applied ones.
+func describeSubjectDrift(current, lastApplied []rbacv1.Subject) string {
+	var added, removed []string
+	for _, subject := range current {
+		if !containsSubject(lastApplied, subject) {
+			added = append(added, describeSubject(subject))
+		}
+	}
+	for _, subject := range lastApplied {
+		if !containsSubject(current, subject) {
+			removed = append(removed, describeSubject(subject))
+		}
+	}
+	return describeDrift("subjects", strings.Join(added, ", "), strings.Join(removed, ", "))
+}
+
+func containsSubject(subjects []rbacv1.Subject, subject rbacv1.Subject) bool {
+	for _, s := range subjects {
+		if s == subject {
+			return true
+		}
+	}
+	return false
+}
+
+func describeSubject(subject rbacv1.Subject) string {
+	if subject.Namespace != "" {
+		return subject.Kind + " " + subject.Namespace + "/" + subject.Name
+	}
+	return subject.Kind + " " + subject.Name
+}
+
+// describeDrift joins the added and removed rules or subjects. Changes, that only reorder them, are no drift and described as empty.
+func describeDrift(what, added, removed string) string {
+	var parts []string
+	if added != "" {
+		parts = append(parts, fmt.Sprintf("added %s %s", what, added))
+	}
+	if removed != "" {
+		parts = append(parts, fmt.Sprintf("removed %s %s", what, removed))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// recordDrift reports a corrected Role or RoleBinding via Events on the object and the role template and via metrics.
+func (r *roleTemplateRollout) recordDrift(obj runtime.Object, kind, drift string) error {
+	templateKind, err := r.templateGroupKind()
+	if err != nil {
+		return err
+	}
+	objMeta := obj.(metav1.Object)
+	r.Log.Info("corrected drift", "kind", kind, "name", objMeta.GetName(), "namespace", objMeta.GetNamespace(), "drift", drift)
+	rbacDriftCorrectionsTotal.WithLabelValues(templateKind.Kind, kind).Inc()
+	r.Recorder.Eventf(obj, corev1.EventTypeWarning, DriftCorrectedReason,
+		"Corrected out-of-band changes to %s: %s", kind, drift)
+	r.Recorder.Eventf(r.template.(runtime.Object), corev1.EventTypeWarning, DriftCorrectedReason,
+		"Corrected out-of-band changes to %s %s/%s: %s", kind, objMeta.GetNamespace(), objMeta.GetName(), drift)
+	return nil
+}
@@ -0,0 +1,396 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8c.io/utils/pkg/owner"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	"k8c.io/bulward/pkg/templates"
+)
+
+// roleTemplateTarget is a target of a role template, as rolled out by the controllers.
+// Existing objects of the role template in the namespace of a target that failed to render are kept, until rendering succeeds again.
+type roleTemplateTarget struct {
+	templates.Target
+	// pending is set, if a staged rollout did not reach the target yet.
+	// Existing Roles of the role template in the namespace keep their rules, until the target is updated.
+	// The RoleBinding is reconciled right away, so subjects that were removed lose their access even while the rollout is paused.
+	pending bool
+	// applied is set, once the target was reconciled, err holds the result.
+	applied bool
+	err     error
+}
+
+// roleTemplateRollout rolls out the Roles and RoleBindings of a role template to all of its targets.
+// A failing target does not block the rollout to the other targets, it's recorded in the rollout status instead.
+type roleTemplateRollout struct {
+	client.Client
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// template owns all Roles and RoleBindings of the rollout.
+	template metav1.Object
+	// controller adds a controller reference to the template, so Roles and RoleBindings are garbage collected.
+	controller bool
+	// revision of the template, that is recorded on the Roles and RoleBindings, if set.
+	revision string
+	// previous is the rollout status of the template, before this rollout.
+	previous corev1alpha1.RoleTemplateRollout
+}
+
+// ownerConflictError is returned, when a Role or RoleBinding of a target already exists and belongs to someone else.
+// The object is left untouched in this case, instead of taking over ownership.
+type ownerConflictError struct {
+	kind      string
+	namespace string
+	name      string
+	// owner describes the competing owner, empty if the object is not managed by any owner.
+	owner string
+}
+
+func (e *ownerConflictError) Error() string {
+	if e.owner == "" {
+		return fmt.Sprintf("%s %s/%s already exists and is not managed by Bulward", e.kind, e.namespace, e.name)
+	}
+	return fmt.Sprintf("%s %s/%s is already owned by %s", e.kind, e.namespace, e.name, e.owner)
+}
+
+// rollout reconciles the Role and RoleBinding of every target and prunes the ones of targets that left the scope.
+// The returned error only reports failed pruning, failed targets are part of the returned rollout status.
+// Targets that failed, because their objects belong to someone else, are returned as conflicts in addition.
+func (r *roleTemplateRollout) rollout(ctx context.Context, targets []roleTemplateTarget) (corev1alpha1.RoleTemplateRollout, []*ownerConflictError, error) {
+	r.apply(ctx, targets)
+	status, conflicts := r.status(targets)
+	return status, conflicts, r.prune(ctx, targets)
+}
+
+// apply reconciles the Role and RoleBinding of every target, that was not applied yet.
+// Only the RoleBinding is reconciled for pending targets.
+func (r *roleTemplateRollout) apply(ctx context.Context, targets []roleTemplateTarget) {
+	for i := range targets {
+		target := &targets[i]
+		if target.applied {
+			continue
+		}
+		target.applied = true
+		switch {
+		case target.pending && target.Err != nil:
+			// Nothing was rendered for the target, its objects are kept until the staged rollout reaches it.
+		case target.pending:
+			target.err = r.reconcileRoleBinding(ctx, *target)
+		case target.Err != nil:
+			target.err = target.Err
+		default:
+			target.err = r.reconcileRole(ctx, *target)
+			if target.err == nil {
+				target.err = r.reconcileRoleBinding(ctx, *target)
+			}
+		}
+		if target.err != nil {
+			r.Log.Error(target.err, "rolling out to target failed", "kind", target.Kind, "name", target.Name)
+		}
+	}
+}
+
+// status summarizes the results of the applied targets.
+// Targets that failed, because their objects belong to someone else, are returned as conflicts in addition.
+func (r *roleTemplateRollout) status(targets []roleTemplateTarget) (corev1alpha1.RoleTemplateRollout, []*ownerConflictError) {
+	status := corev1alpha1.RoleTemplateRollout{Targets: len(targets)}
+	var conflicts []*ownerConflictError
+	now := metav1.Now()
+	for _, target := range targets {
+		switch {
+		case target.err == nil && target.pending:
+			status.Pending++
+			continue
+		case target.err == nil:
+			status.Ready++
+			continue
+		}
+		status.Failed++
+		var conflict *ownerConflictError
+		if errors.As(target.err, &conflict) {
+			conflicts = append(conflicts, conflict)
+		}
+		if len(status.FailedTargets) < corev1alpha1.MaxFailedTargets {
+			failed := target.RoleTemplateTarget
+			failed.State = corev1alpha1.RoleTemplateTargetFailed
+			failed.Message = target.err.Error()
+			failed.LastAttemptTime = r.lastAttemptTime(failed, &now)
+			status.FailedTargets = append(status.FailedTargets, failed)
+		}
+	}
+	return status, conflicts
+}
+
+// lastAttemptTime keeps the LastAttemptTime of targets that keep failing the same way,
+// so the status of the template isn't updated on every retry.
+func (r *roleTemplateRollout) lastAttemptTime(failed corev1alpha1.RoleTemplateTarget, now *metav1.Time) *metav1.Time {
+	for _, previous := range r.previous.FailedTargets {
+		if previous.Kind == failed.Kind && previous.APIGroup == failed.APIGroup && previous.Name == failed.Name &&
+			previous.Message == failed.Message && previous.LastAttemptTime != nil {
+			return previous.LastAttemptTime
+		}
+	}
+	return now
+}
+
+// reconcileRole creates or updates the Role of a single target.
+func (r *roleTemplateRollout) reconcileRole(ctx context.Context, target roleTemplateTarget) error {
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{
+		Name:      target.Role.Name,
+		Namespace: target.Role.Namespace,
+	}}
+	// Rules changed by others are detected before they are overwritten, so the correction can be reported.
+	var roleDrift string
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		if err := r.checkOwner("Role", role); err != nil {
+			return err
+		}
+		if err := r.setOwner(role); err != nil {
+			return err
+		}
+		r.setRevision(role)
+		var lastApplied []rbacv1.PolicyRule
+		drifted, err := hasDrifted(role, role.Rules, &lastApplied)
+		if err != nil {
+			return fmt.Errorf("checking rules for drift: %w", err)
+		}
+		if drifted {
+			roleDrift = describeRuleDrift(role.Rules, lastApplied)
+		}
+		role.Rules = target.Role.Rules
+		return setLastAppliedRBAC(role, role.Rules)
+	}); err != nil {
+		return fmt.Errorf("reconciling Role %s/%s: %w", role.Namespace, role.Name, err)
+	}
+	if roleDrift != "" {
+		if err := r.recordDrift(role, "Role", roleDrift); err != nil {
+			return fmt.Errorf("recording drift of Role %s/%s: %w", role.Namespace, role.Name, err)
+		}
+	}
+	return nil
+}
+
+// reconcileRoleBinding creates or updates the RoleBinding of a single target, if the role template is bound to anyone.
+func (r *roleTemplateRollout) reconcileRoleBinding(ctx context.Context, target roleTemplateTarget) error {
+	if target.RoleBinding == nil {
+		return nil
+	}
+	roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+		Name:      target.RoleBinding.Name,
+		Namespace: target.RoleBinding.Namespace,
+	}}
+	var roleBindingDrift string
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, roleBinding, func() error {
+		if err := r.checkOwner("RoleBinding", roleBinding); err != nil {
+			return err
+		}
+		if err := r.setOwner(roleBinding); err != nil {
+			return err
+		}
+		r.setRevision(roleBinding)
+		var lastApplied []rbacv1.Subject
+		drifted, err := hasDrifted(roleBinding, defaultSubjects(roleBinding.Subjects), &lastApplied)
+		if err != nil {
+			return fmt.Errorf("checking subjects for drift: %w", err)
+		}
+		if drifted {
+			roleBindingDrift = describeSubjectDrift(defaultSubjects(roleBinding.Subjects), lastApplied)
+		}
+		roleBinding.RoleRef = target.RoleBinding.RoleRef
+		roleBinding.Subjects = target.RoleBinding.Subjects
+		return setLastAppliedRBAC(roleBinding, defaultSubjects(roleBinding.Subjects))
+	}); err != nil {
+		return fmt.Errorf("reconciling RoleBinding %s/%s: %w", roleBinding.Namespace, roleBinding.Name, err)
+	}
+	if roleBindingDrift != "" {
+		if err := r.recordDrift(roleBinding, "RoleBinding", roleBindingDrift); err != nil {
+			return fmt.Errorf("recording drift of RoleBinding %s/%s: %w", roleBinding.Namespace, roleBinding.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkOwner returns an ownerConflictError, if the object already exists and is not owned by the role template.
+func (r *roleTemplateRollout) checkOwner(kind string, obj metav1.Object) error {
+	if obj.GetResourceVersion() == "" {
+		// The object doesn't exist yet.
+		return nil
+	}
+	labels := obj.GetLabels()
+	ownerType := labels[owner.OwnerTypeLabel]
+	ownerNamespace := labels[owner.OwnerNamespaceLabel]
+	ownerName := labels[owner.OwnerNameLabel]
+	templateKind, err := r.templateGroupKind()
+	if err != nil {
+		return err
+	}
+	if ownerType == templateKind.String() && ownerNamespace == r.template.GetNamespace() && ownerName == r.template.GetName() {
+		return nil
+	}
+
+	conflict := &ownerConflictError{kind: kind, namespace: obj.GetNamespace(), name: obj.GetName()}
+	switch {
+	case ownerName == "":
+		conflict.owner = ownerType
+	case ownerNamespace == "":
+		conflict.owner = strings.TrimSpace(ownerType + " " + ownerName)
+	default:
+		conflict.owner = strings.TrimSpace(ownerType + " " + ownerNamespace + "/" + ownerName)
+	}
+	return conflict
+}
+
+// templateGroupKind returns the GroupKind of the role template.
+func (r *roleTemplateRollout) templateGroupKind() (schema.GroupKind, error) {
+	gvk, err := apiutil.GVKForObject(r.template.(runtime.Object), r.Scheme)
+	if err != nil {
+		return schema.GroupKind{}, fmt.Errorf("getting GroupVersionKind of role template: %w", err)
+	}
+	return gvk.GroupKind(), nil
+}
+
+// setOwner marks the object as owned by the role template and as managed by Bulward.
+func (r *roleTemplateRollout) setOwner(obj metav1.Object) error {
+	templateObj := r.template.(runtime.Object)
+	if _, err := owner.SetOwnerReference(templateObj, obj.(runtime.Object), r.Scheme); err != nil {
+		return fmt.Errorf("setting owner reference: %w", err)
+	}
+	labels := obj.GetLabels()
+	labels[corev1alpha1.ManagedLabel] = "true"
+	obj.SetLabels(labels)
+	if !r.controller {
+		return nil
+	}
+	if err := controllerutil.SetControllerReference(r.template, obj, r.Scheme); err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+	return nil
+}
+
+// setRevision records the revision of the role template on the object.
+func (r *roleTemplateRollout) setRevision(obj metav1.Object) {
+	if r.revision == "" {
+		return
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[corev1alpha1.RoleTemplateRevisionAnnotation] = r.revision
+	obj.SetAnnotations(annotations)
+}
+
+// prune deletes the Roles and RoleBindings of the role template that don't belong to any target anymore.
+func (r *roleTemplateRollout) prune(ctx context.Context, targets []roleTemplateTarget) error {
+	desiredRoles := map[types.NamespacedName]bool{}
+	desiredRoleBindings := map[types.NamespacedName]bool{}
+	keptNamespaces := map[string]bool{}
+	// Roles of pending targets are kept until the staged rollout reaches them, their RoleBindings are up-to-date already.
+	keptRoleNamespaces := map[string]bool{}
+	for _, target := range targets {
+		if target.Err != nil {
+			keptNamespaces[target.Namespace] = true
+			continue
+		}
+		if target.pending {
+			keptRoleNamespaces[target.Namespace] = true
+		} else {
+			desiredRoles[types.NamespacedName{Name: target.Role.Name, Namespace: target.Role.Namespace}] = true
+		}
+		if target.RoleBinding != nil {
+			desiredRoleBindings[types.NamespacedName{Name: target.RoleBinding.Name, Namespace: target.RoleBinding.Namespace}] = true
+		}
+	}
+
+	var errs []error
+	roles := &rbacv1.RoleList{}
+	if err := r.List(ctx, roles, owner.OwnedBy(r.template.(runtime.Object), r.Scheme)); err != nil {
+		return fmt.Errorf("listing Roles: %w", err)
+	}
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		if keptNamespaces[role.Namespace] || keptRoleNamespaces[role.Namespace] || desiredRoles[types.NamespacedName{Name: role.Name, Namespace: role.Namespace}] {
+			continue
+		}
+		if err := r.Delete(ctx, role); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("deleting Role %s/%s: %w", role.Namespace, role.Name, err))
+		}
+	}
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := r.List(ctx, roleBindings, owner.OwnedBy(r.template.(runtime.Object), r.Scheme)); err != nil {
+		return fmt.Errorf("listing RoleBindings: %w", err)
+	}
+	for i := range roleBindings.Items {
+		roleBinding := &roleBindings.Items[i]
+		if keptNamespaces[roleBinding.Namespace] || desiredRoleBindings[types.NamespacedName{Name: roleBinding.Name, Namespace: roleBinding.Namespace}] {
+			continue
+		}
+		if err := r.Delete(ctx, roleBinding); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("deleting RoleBinding %s/%s: %w", roleBinding.Namespace, roleBinding.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// conflictMessage describes the conflicts of a rollout for the Conflict condition of role templates.
+// Only the first conflicts are named, so the condition stays small.
+func conflictMessage(conflicts []*ownerConflictError) string {
+	if len(conflicts) == 0 {
+		return "No Roles or RoleBindings are owned by someone else."
+	}
+	var messages []string
+	for i, conflict := range conflicts {
+		if i == corev1alpha1.MaxFailedTargets {
+			messages = append(messages, fmt.Sprintf("and %d more", len(conflicts)-i))
+			break
+		}
+		messages = append(messages, conflict.Error())
+	}
+	return strings.Join(messages, ", ") + "."
+}
+
+// rolloutMessage describes the rollout status for the Degraded condition of role templates.
+func rolloutMessage(status corev1alpha1.RoleTemplateRollout) string {
+	if status.Failed == 0 && status.Pending == 0 {
+		return fmt.Sprintf("Rolled out to all %d targets.", status.Targets)
+	}
+	if status.Failed == 0 {
+		return fmt.Sprintf("Rolled out to %d of %d targets, %d are pending.", status.Ready, status.Targets, status.Pending)
+	}
+	return fmt.Sprintf("Rollout failed for %d of %d targets.", status.Failed, status.Targets)
+}
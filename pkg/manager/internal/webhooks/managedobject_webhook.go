@@ -0,0 +1,171 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8c.io/utils/pkg/owner"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+// controlPlaneServiceAccountPrefix is the username prefix of the controllers of Kubernetes itself,
+// like the garbage collector and the namespace controller, that clean up managed objects.
+const controlPlaneServiceAccountPrefix = "system:serviceaccount:kube-system:"
+
+// controllerManagerUsername is the username of the kube-controller-manager,
+// when it runs without --use-service-account-credentials and all its controllers share one identity.
+const controllerManagerUsername = "system:kube-controller-manager"
+
+// clusterAdminGroup is the group of cluster admins, that are no tenants.
+const clusterAdminGroup = "system:masters"
+
+// ManagedObjectWebhookHandler protects the Namespaces, Roles and RoleBindings managed by Bulward.
+// Tenants must not update or delete managed Roles and RoleBindings, they are changed via their role template instead.
+// Managed Namespaces are only deleted by the manager, when their Organization or Project is deleted.
+// Tenants must not create objects with the ManagedLabel or owner labels either, as the controllers trust them
+// to tell objects of role templates from user created ones.
+// The webhooks are limited to objects with the ManagedLabel or the owner type label and fail closed,
+// because deleted Namespaces and their contents can't be restored by the controllers. While the manager is unavailable,
+// managed objects can't be changed at all. The objectSelectors are set in config/manager/default,
+// as controller-gen can't generate them.
+type ManagedObjectWebhookHandler struct {
+	decoder *admission.Decoder
+	// ManagerUsername is the username of the manager itself.
+	ManagerUsername string
+	Log             logr.Logger
+}
+
+var _ admission.Handler = (*ManagedObjectWebhookHandler)(nil)
+var _ admission.DecoderInjector = (*ManagedObjectWebhookHandler)(nil)
+
+// +kubebuilder:webhook:path=/validate-rbac-authorization-k8s-io-v1-role,mutating=false,failurePolicy=fail,groups=rbac.authorization.k8s.io,resources=roles,verbs=create;update;delete,versions=v1,name=vrole.bulward.io
+// +kubebuilder:webhook:path=/validate-rbac-authorization-k8s-io-v1-role,mutating=false,failurePolicy=fail,groups=rbac.authorization.k8s.io,resources=roles,verbs=create;update,versions=v1,name=vroleowner.bulward.io
+// +kubebuilder:webhook:path=/validate-rbac-authorization-k8s-io-v1-rolebinding,mutating=false,failurePolicy=fail,groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create;update;delete,versions=v1,name=vrolebinding.bulward.io
+// +kubebuilder:webhook:path=/validate-rbac-authorization-k8s-io-v1-rolebinding,mutating=false,failurePolicy=fail,groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=create;update,versions=v1,name=vrolebindingowner.bulward.io
+// +kubebuilder:webhook:path=/validate-v1-namespace,mutating=false,failurePolicy=fail,groups="",resources=namespaces,verbs=create;delete,versions=v1,name=vnamespace.bulward.io
+// +kubebuilder:webhook:path=/validate-v1-namespace,mutating=false,failurePolicy=fail,groups="",resources=namespaces,verbs=create,versions=v1,name=vnamespaceowner.bulward.io
+
+// Handle is the function to handle create/update/delete requests of Namespaces, Roles and RoleBindings.
+func (r *ManagedObjectWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if r.isAllowed(req.UserInfo, req.Kind.Kind) {
+		return admission.Allowed("allowed to commit the request")
+	}
+
+	if req.Operation != admissionv1beta1.Create {
+		// The old object is checked, so tenants can't remove the labels in the same update.
+		oldObj := &unstructured.Unstructured{}
+		if err := r.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if oldObj.GetLabels()[corev1alpha1.ManagedLabel] == "true" {
+			r.Log.Info("deny change of managed object", "kind", req.Kind.Kind, "name", req.Name, "namespace", req.Namespace, "user", req.UserInfo.Username)
+			verb := "changed"
+			if req.Operation == admissionv1beta1.Delete {
+				verb = "deleted"
+			}
+			return admission.Denied(fmt.Sprintf("%s %s is managed by %s and can't be %s, change the %s instead",
+				req.Kind.Kind, describeObject(oldObj.GetNamespace(), oldObj.GetName()), describeOwner(oldObj), verb, ownerKind(oldObj)))
+		}
+	}
+	if req.Operation == admissionv1beta1.Delete {
+		return admission.Allowed("allowed to commit the request")
+	}
+
+	// Objects must not pose as managed ones, controllers would take them for objects of Bulward.
+	obj := &unstructured.Unstructured{}
+	if err := r.decoder.DecodeRaw(req.Object, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if label, ok := reservedLabel(obj); ok {
+		r.Log.Info("deny reserved label", "kind", req.Kind.Kind, "name", req.Name, "namespace", req.Namespace, "user", req.UserInfo.Username, "label", label)
+		return admission.Denied(fmt.Sprintf("%s %s must not carry the label %s, it's reserved for objects managed by Bulward",
+			req.Kind.Kind, describeObject(obj.GetNamespace(), obj.GetName()), label))
+	}
+	return admission.Allowed("allowed to commit the request")
+}
+
+// InjectDecoder injects the decoder into the ManagedObjectWebhookHandler.
+func (r *ManagedObjectWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	r.decoder = d
+	return nil
+}
+
+// isAllowed checks if the user may change managed objects of the given kind.
+// Cluster admins are no tenants and may change managed Roles and RoleBindings, but not delete managed Namespaces.
+func (r *ManagedObjectWebhookHandler) isAllowed(userInfo authenticationv1.UserInfo, kind string) bool {
+	if userInfo.Username == r.ManagerUsername ||
+		userInfo.Username == controllerManagerUsername ||
+		strings.HasPrefix(userInfo.Username, controlPlaneServiceAccountPrefix) {
+		return true
+	}
+	if kind == "Namespace" {
+		return false
+	}
+	for _, group := range userInfo.Groups {
+		if group == clusterAdminGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// reservedLabel returns the first label of the object, that is reserved for objects managed by Bulward.
+func reservedLabel(obj *unstructured.Unstructured) (string, bool) {
+	labels := obj.GetLabels()
+	for _, label := range []string{corev1alpha1.ManagedLabel, owner.OwnerTypeLabel, owner.OwnerNameLabel, owner.OwnerNamespaceLabel} {
+		if _, ok := labels[label]; ok {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// describeOwner names the owner of the managed object, e.g. `OrganizationRoleTemplate "rbac-admin"`.
+// Organizations are named by their user facing name, which is kept on their Namespaces.
+func describeOwner(obj *unstructured.Unstructured) string {
+	labels := obj.GetLabels()
+	kind := ownerKind(obj)
+	name := labels[owner.OwnerNameLabel]
+	if alias := labels[storagev1alpha1.OrganizationNameLabel]; kind == "Organization" && alias != "" {
+		name = alias
+	}
+	return kind + " " + describeObject(labels[owner.OwnerNamespaceLabel], name)
+}
+
+// ownerKind returns the kind of the owner of the managed object.
+func ownerKind(obj *unstructured.Unstructured) string {
+	return schema.ParseGroupKind(obj.GetLabels()[owner.OwnerTypeLabel]).Kind
+}
+
+func describeObject(namespace, name string) string {
+	if namespace == "" {
+		return fmt.Sprintf("%q", name)
+	}
+	return fmt.Sprintf("%q", namespace+"/"+name)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	"k8c.io/bulward/pkg/validation"
+)
+
+// OrganizationRoleTemplateWebhookHandler handles validating of OrganizationRoleTemplates.
+type OrganizationRoleTemplateWebhookHandler struct {
+	decoder *admission.Decoder
+	Log     logr.Logger
+}
+
+var _ admission.Handler = (*OrganizationRoleTemplateWebhookHandler)(nil)
+var _ admission.DecoderInjector = (*OrganizationRoleTemplateWebhookHandler)(nil)
+
+// +kubebuilder:webhook:path=/validate-bulward-io-v1alpha1-organizationroletemplate,mutating=false,failurePolicy=fail,groups=bulward.io,resources=organizationroletemplates,verbs=create;update,versions=v1alpha1,name=vorganizationroletemplate.bulward.io
+
+// Handle is the function to handle create/update requests of OrganizationRoleTemplates.
+func (r *OrganizationRoleTemplateWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &corev1alpha1.OrganizationRoleTemplate{}
+	if err := r.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if req.Operation == admissionv1beta1.Update {
+		oldObj := &corev1alpha1.OrganizationRoleTemplate{}
+		if err := r.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(oldObj.Spec, obj.Spec) {
+			// Spec is unchanged, don't block e.g. finalizer removal on deletion
+			// of templates, that were created before a validation was added.
+			return admission.Allowed("allowed to commit the request")
+		}
+	}
+	return r.validate(obj)
+}
+
+// InjectDecoder injects the decoder into the OrganizationRoleTemplateWebhookHandler.
+func (r *OrganizationRoleTemplateWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	r.decoder = d
+	return nil
+}
+
+func (r *OrganizationRoleTemplateWebhookHandler) validate(template *corev1alpha1.OrganizationRoleTemplate) admission.Response {
+	r.Log.Info("validate", "name", template.Name)
+	if errs := validation.ValidateOrganizationRoleTemplateSpec(&template.Spec, field.NewPath("spec")); len(errs) > 0 {
+		return invalid(corev1alpha1.GroupVersion.WithKind("OrganizationRoleTemplate").GroupKind(), template.Name, errs)
+	}
+	return admission.Allowed("allowed to commit the request")
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	adminv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/validation"
+)
+
+// ProjectWebhookHandler handles validating of Projects.
+type ProjectWebhookHandler struct {
+	decoder *admission.Decoder
+	// Reader is used to look up ServiceAccount owners,
+	// it should not be backed by the cache to not watch all ServiceAccounts in the cluster.
+	Reader client.Reader
+	Log    logr.Logger
+}
+
+var _ admission.Handler = (*ProjectWebhookHandler)(nil)
+var _ admission.DecoderInjector = (*ProjectWebhookHandler)(nil)
+
+// +kubebuilder:webhook:path=/validate-storage-bulward-io-v1alpha1-project,mutating=false,failurePolicy=fail,groups=storage.bulward.io,resources=projects,verbs=create;update,versions=v1alpha1,name=vproject.bulward.io
+
+// Handle is the function to handle create/update requests of Projects.
+func (r *ProjectWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &storagev1alpha1.Project{}
+	if err := r.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	switch req.Operation {
+	case adminv1beta1.Create:
+		return r.validateOwners(ctx, obj)
+	case adminv1beta1.Update:
+		oldObj := &storagev1alpha1.Project{}
+		if err := r.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(oldObj.Spec.Owners, obj.Spec.Owners) {
+			// Owners are unchanged, don't block e.g. finalizer removal on deletion
+			// when the owning ServiceAccounts are already gone.
+			break
+		}
+		return r.validateOwners(ctx, obj)
+	}
+	return admission.Allowed("allowed to commit the request")
+}
+
+// InjectDecoder injects the decoder into the ProjectWebhookHandler.
+func (r *ProjectWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	r.decoder = d
+	return nil
+}
+
+func (r *ProjectWebhookHandler) validateOwners(ctx context.Context, project *storagev1alpha1.Project) admission.Response {
+	r.Log.Info("validate owners", "name", project.Name)
+	errs, err := validation.ValidateOwners(ctx, r.Reader, project.Spec.Owners, field.NewPath("spec", "owners"))
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(errs) > 0 {
+		return invalid(storagev1alpha1.SchemeGroupVersion.WithKind("Project").GroupKind(), project.Name, errs)
+	}
+	return admission.Allowed("allowed to commit the request")
+}
@@ -0,0 +1,314 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	"k8c.io/bulward/pkg/templates"
+	"k8c.io/bulward/pkg/validation"
+)
+
+// ProjectRoleTemplateWebhookHandler handles validating of ProjectRoleTemplates.
+// Roles of ProjectRoleTemplates are created by the manager, so the requesting user must hold every
+// permission of the template rules, just like when creating the Role directly.
+type ProjectRoleTemplateWebhookHandler struct {
+	decoder *admission.Decoder
+	// Client is used to create SubjectAccessReviews and to look up the Namespace and included templates.
+	Client client.Client
+	Log    logr.Logger
+}
+
+var _ admission.Handler = (*ProjectRoleTemplateWebhookHandler)(nil)
+var _ admission.DecoderInjector = (*ProjectRoleTemplateWebhookHandler)(nil)
+
+// +kubebuilder:webhook:path=/validate-bulward-io-v1alpha1-projectroletemplate,mutating=false,failurePolicy=fail,groups=bulward.io,resources=projectroletemplates,verbs=create;update,versions=v1alpha1,name=vprojectroletemplate.bulward.io
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Handle is the function to handle create/update requests of ProjectRoleTemplates.
+func (r *ProjectRoleTemplateWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &corev1alpha1.ProjectRoleTemplate{}
+	if err := r.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	grantsChanged := true
+	if req.Operation == admissionv1beta1.Update {
+		oldObj := &corev1alpha1.ProjectRoleTemplate{}
+		if err := r.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if reflect.DeepEqual(oldObj.Spec, obj.Spec) {
+			// Spec is unchanged, don't block e.g. finalizer removal on deletion
+			// of templates, that were created before a validation was added.
+			return admission.Allowed("allowed to commit the request")
+		}
+		grantsChanged = !sameGrants(&oldObj.Spec, &obj.Spec)
+	}
+	errs := validation.ValidateProjectRoleTemplateSpec(&obj.Spec, field.NewPath("spec"))
+	namespaceErrs, err := validation.ValidateProjectRoleTemplateNamespace(ctx, r.Client, obj.Namespace, field.NewPath("metadata", "namespace"))
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if errs = append(errs, namespaceErrs...); len(errs) > 0 {
+		return invalid(corev1alpha1.GroupVersion.WithKind("ProjectRoleTemplate").GroupKind(), obj.Name, errs)
+	}
+	if !grantsChanged {
+		// Unrelated changes, like bindTo, must not fail because the user doesn't hold the permissions granted by someone else.
+		return admission.Allowed("allowed to commit the request")
+	}
+	return r.validateRules(ctx, req.UserInfo, obj)
+}
+
+// InjectDecoder injects the decoder into the ProjectRoleTemplateWebhookHandler.
+func (r *ProjectRoleTemplateWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	r.decoder = d
+	return nil
+}
+
+// validateRules checks that the user is allowed to perform everything the template rules grant
+// and to bind the referenced ClusterRole. Rules generated from CustomResourceDefinitions require the escalate permission.
+// The same applies to the rules of all included templates, that have to exist.
+// Permissions are checked in the namespace of the template, which stands in for Projects created later,
+// and in the namespaces of all Projects the template is rolled out to.
+// Users that may escalate Roles in a namespace are allowed to grant any permission there, like for plain Roles.
+func (r *ProjectRoleTemplateWebhookHandler) validateRules(ctx context.Context, userInfo authenticationv1.UserInfo, template *corev1alpha1.ProjectRoleTemplate) admission.Response {
+	r.Log.Info("validate rules", "name", template.Name, "namespace", template.Namespace, "user", userInfo.Username)
+	projectNamespaces, err := templates.ProjectRoleTemplateNamespaces(ctx, r.Client, template)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	var errs field.ErrorList
+	includesPath := field.NewPath("spec", "includes")
+	included, err := templates.ResolveIncludes(ctx, r.Client, templates.ProjectRoleTemplateKey(template), template.Spec.Includes)
+	if err != nil {
+		errs = append(errs, field.Invalid(includesPath, template.Spec.Includes, err.Error()))
+	}
+	for _, includedTemplate := range included {
+		for _, rule := range includedTemplate.Rules {
+			if len(rule.NonResourceURLs) > 0 {
+				errs = append(errs, field.Invalid(includesPath, includedTemplate.String(), "namespaced rules cannot apply to non-resource URLs"))
+			}
+		}
+	}
+
+	for _, namespace := range append([]string{template.Namespace}, projectNamespaces...) {
+		namespaceErrs, err := r.validateRulesInNamespace(ctx, userInfo, template, included, namespace)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		errs = append(errs, namespaceErrs...)
+	}
+	if len(errs) > 0 {
+		return invalid(corev1alpha1.GroupVersion.WithKind("ProjectRoleTemplate").GroupKind(), template.Name, errs)
+	}
+	return admission.Allowed("allowed to commit the request")
+}
+
+// validateRulesInNamespace checks the rules of the template and its included templates in a single namespace.
+func (r *ProjectRoleTemplateWebhookHandler) validateRulesInNamespace(ctx context.Context, userInfo authenticationv1.UserInfo, template *corev1alpha1.ProjectRoleTemplate, included []templates.IncludedTemplate, namespace string) (field.ErrorList, error) {
+	canEscalate, err := r.isAllowed(ctx, userInfo, authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "escalate",
+		Group:     rbacv1.GroupName,
+		Resource:  "roles",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if canEscalate {
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	rulesPath := field.NewPath("spec", "rules")
+	for i, rule := range template.Spec.Rules {
+		for _, attributes := range ruleResourceAttributes(namespace, rule) {
+			allowed, err := r.isAllowed(ctx, userInfo, attributes)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				errs = append(errs, field.Forbidden(rulesPath.Index(i), fmt.Sprintf("user %q is not allowed to grant %s", userInfo.Username, describeResourceAttributes(attributes))))
+			}
+		}
+	}
+	if ref := template.Spec.ClusterRoleRef; ref != nil {
+		// Rules of the ClusterRole are copied into Roles, so the user must be allowed to bind it in this namespace.
+		attributes := authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "bind",
+			Group:     rbacv1.GroupName,
+			Resource:  "clusterroles",
+			Name:      ref.Name,
+		}
+		allowed, err := r.isAllowed(ctx, userInfo, attributes)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			errs = append(errs, field.Forbidden(field.NewPath("spec", "clusterRoleRef"), fmt.Sprintf("user %q is not allowed to %s", userInfo.Username, describeResourceAttributes(attributes))))
+		}
+	}
+	if len(template.Spec.CRDRules) > 0 {
+		// Generated rules follow the installed CustomResourceDefinitions, so they can't be checked upfront.
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "crdRules"), fmt.Sprintf("user %q is not allowed to escalate roles in namespace %s, which is required for rules generated from CustomResourceDefinitions", userInfo.Username, namespace)))
+	}
+
+	// Included templates grant their rules as well, so they are checked like the own rules of the template.
+	includesPath := field.NewPath("spec", "includes")
+	for _, includedTemplate := range included {
+		for _, rule := range includedTemplate.Rules {
+			if len(rule.NonResourceURLs) > 0 {
+				continue
+			}
+			for _, attributes := range ruleResourceAttributes(namespace, rule) {
+				allowed, err := r.isAllowed(ctx, userInfo, attributes)
+				if err != nil {
+					return nil, err
+				}
+				if !allowed {
+					errs = append(errs, field.Forbidden(includesPath, fmt.Sprintf("user %q is not allowed to grant %s included from %s", userInfo.Username, describeResourceAttributes(attributes), includedTemplate)))
+				}
+			}
+		}
+		if ref := includedTemplate.ClusterRoleRef; ref != nil {
+			attributes := authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "bind",
+				Group:     rbacv1.GroupName,
+				Resource:  "clusterroles",
+				Name:      ref.Name,
+			}
+			allowed, err := r.isAllowed(ctx, userInfo, attributes)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				errs = append(errs, field.Forbidden(includesPath, fmt.Sprintf("user %q is not allowed to %s included from %s", userInfo.Username, describeResourceAttributes(attributes), includedTemplate)))
+			}
+		}
+		if len(includedTemplate.CRDRules) > 0 {
+			errs = append(errs, field.Forbidden(includesPath, fmt.Sprintf("user %q is not allowed to escalate roles in namespace %s, which is required for rules generated from CustomResourceDefinitions included from %s", userInfo.Username, namespace, includedTemplate)))
+		}
+	}
+	return errs, nil
+}
+
+// sameGrants returns whether both specs grant the same permissions to the same Projects.
+func sameGrants(old, new *corev1alpha1.ProjectRoleTemplateSpec) bool {
+	return reflect.DeepEqual(old.Rules, new.Rules) &&
+		reflect.DeepEqual(old.ClusterRoleRef, new.ClusterRoleRef) &&
+		reflect.DeepEqual(old.CRDRules, new.CRDRules) &&
+		reflect.DeepEqual(old.Includes, new.Includes) &&
+		reflect.DeepEqual(old.ProjectSelector, new.ProjectSelector)
+}
+
+// isAllowed checks via SubjectAccessReview whether the user is allowed to access the given resource.
+func (r *ProjectRoleTemplateWebhookHandler) isAllowed(ctx context.Context, userInfo authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range userInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               userInfo.Username,
+			Groups:             userInfo.Groups,
+			UID:                userInfo.UID,
+			Extra:              extra,
+		},
+	}
+	if err := r.Client.Create(ctx, sar); err != nil {
+		return false, fmt.Errorf("creating SubjectAccessReview: %w", err)
+	}
+	return sar.Status.Allowed, nil
+}
+
+// ruleResourceAttributes expands the PolicyRule into the single permissions it grants.
+// Resource names with placeholders are rendered differently per target, so they are checked as any name.
+func ruleResourceAttributes(namespace string, rule rbacv1.PolicyRule) []authorizationv1.ResourceAttributes {
+	var resourceNames []string
+	for _, name := range rule.ResourceNames {
+		if templates.HasPlaceholders(name) {
+			name = ""
+		}
+		resourceNames = append(resourceNames, name)
+	}
+	if len(resourceNames) == 0 {
+		resourceNames = []string{""}
+	}
+	var attributes []authorizationv1.ResourceAttributes
+	for _, group := range rule.APIGroups {
+		for _, resource := range rule.Resources {
+			resource, subresource := splitResource(resource)
+			for _, verb := range rule.Verbs {
+				for _, name := range resourceNames {
+					attributes = append(attributes, authorizationv1.ResourceAttributes{
+						Namespace:   namespace,
+						Verb:        verb,
+						Group:       group,
+						Resource:    resource,
+						Subresource: subresource,
+						Name:        name,
+					})
+				}
+			}
+		}
+	}
+	return attributes
+}
+
+// splitResource splits a resource like "pods/log" into resource and subresource.
+func splitResource(resource string) (string, string) {
+	parts := strings.SplitN(resource, "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+func describeResourceAttributes(attributes authorizationv1.ResourceAttributes) string {
+	resource := attributes.Resource
+	if attributes.Subresource != "" {
+		resource += "/" + attributes.Subresource
+	}
+	if attributes.Group != "" {
+		resource += "." + attributes.Group
+	}
+	if attributes.Name != "" {
+		resource += " " + attributes.Name
+	}
+	if attributes.Namespace != "" {
+		resource += " in namespace " + attributes.Namespace
+	}
+	return attributes.Verb + " " + resource
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// OrganizationRoleTemplateDefaultingWebhookHandler handles defaulting of OrganizationRoleTemplates.
+// New templates that don't specify whom to bind their Roles to are bound to the owners.
+type OrganizationRoleTemplateDefaultingWebhookHandler struct {
+	decoder *admission.Decoder
+	Log     logr.Logger
+}
+
+var _ admission.Handler = (*OrganizationRoleTemplateDefaultingWebhookHandler)(nil)
+var _ admission.DecoderInjector = (*OrganizationRoleTemplateDefaultingWebhookHandler)(nil)
+
+// +kubebuilder:webhook:path=/mutate-bulward-io-v1alpha1-organizationroletemplate,mutating=true,failurePolicy=fail,groups=bulward.io,resources=organizationroletemplates,verbs=create,versions=v1alpha1,name=morganizationroletemplate.bulward.io
+
+// Handle is the function to handle create requests of OrganizationRoleTemplates.
+func (r *OrganizationRoleTemplateDefaultingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &corev1alpha1.OrganizationRoleTemplate{}
+	if err := r.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Existing templates are never defaulted, so templates that were left unbound on purpose stay unbound.
+	if req.Operation != admissionv1beta1.Create || len(obj.Spec.BindTo) > 0 {
+		return admission.Allowed("nothing to default")
+	}
+	r.Log.Info("default bindTo", "name", obj.Name)
+	obj.Spec.BindTo = []corev1alpha1.BindingType{corev1alpha1.BindToOwners}
+	return patchResponse(req, obj)
+}
+
+// InjectDecoder injects the decoder into the OrganizationRoleTemplateDefaultingWebhookHandler.
+func (r *OrganizationRoleTemplateDefaultingWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	r.decoder = d
+	return nil
+}
+
+// ProjectRoleTemplateDefaultingWebhookHandler handles defaulting of ProjectRoleTemplates.
+// New templates that don't specify whom to bind their Roles to are bound to the owners.
+type ProjectRoleTemplateDefaultingWebhookHandler struct {
+	decoder *admission.Decoder
+	Log     logr.Logger
+}
+
+var _ admission.Handler = (*ProjectRoleTemplateDefaultingWebhookHandler)(nil)
+var _ admission.DecoderInjector = (*ProjectRoleTemplateDefaultingWebhookHandler)(nil)
+
+// +kubebuilder:webhook:path=/mutate-bulward-io-v1alpha1-projectroletemplate,mutating=true,failurePolicy=fail,groups=bulward.io,resources=projectroletemplates,verbs=create,versions=v1alpha1,name=mprojectroletemplate.bulward.io
+
+// Handle is the function to handle create requests of ProjectRoleTemplates.
+func (r *ProjectRoleTemplateDefaultingWebhookHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	obj := &corev1alpha1.ProjectRoleTemplate{}
+	if err := r.decoder.Decode(req, obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Existing templates are never defaulted, so templates that were left unbound on purpose stay unbound.
+	if req.Operation != admissionv1beta1.Create || len(obj.Spec.BindTo) > 0 {
+		return admission.Allowed("nothing to default")
+	}
+	r.Log.Info("default bindTo", "name", obj.Name, "namespace", obj.Namespace)
+	obj.Spec.BindTo = []corev1alpha1.BindingType{corev1alpha1.BindToOwners}
+	return patchResponse(req, obj)
+}
+
+// InjectDecoder injects the decoder into the ProjectRoleTemplateDefaultingWebhookHandler.
+func (r *ProjectRoleTemplateDefaultingWebhookHandler) InjectDecoder(d *admission.Decoder) error {
+	r.decoder = d
+	return nil
+}
+
+// patchResponse returns a response patching the object of the request into the given defaulted object.
+func patchResponse(req admission.Request, obj interface{}) admission.Response {
+	marshaled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// GenerateValidateWebhookPath returns the path a validating webhook for the given object type is served at.
+// The path matches the path in the `+kubebuilder:webhook` marker of the handler.
+func GenerateValidateWebhookPath(obj runtime.Object, scheme *runtime.Scheme) string {
+	return generateWebhookPath("/validate-", obj, scheme)
+}
+
+// GenerateMutateWebhookPath returns the path a mutating webhook for the given object type is served at.
+// The path matches the path in the `+kubebuilder:webhook` marker of the handler.
+func GenerateMutateWebhookPath(obj runtime.Object, scheme *runtime.Scheme) string {
+	return generateWebhookPath("/mutate-", obj, scheme)
+}
+
+func generateWebhookPath(prefix string, obj runtime.Object, scheme *runtime.Scheme) string {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		// All objects passed here are registered to the manager scheme, this only happens on programming errors.
+		panic(err)
+	}
+	if gvk.Group == "" {
+		// Objects of the core API group, like Namespaces.
+		return prefix + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+	}
+	return prefix + strings.Replace(gvk.Group, ".", "-", -1) + "-" + gvk.Version + "-" + strings.ToLower(gvk.Kind)
+}
+
+// invalid returns a denied admission.Response carrying an Invalid status error for the given field errors,
+// so clients get the same error as from the API validation.
+func invalid(gk schema.GroupKind, name string, errs field.ErrorList) admission.Response {
+	statusErr := apierrors.NewInvalid(gk, name, errs)
+	return admission.Response{
+		AdmissionResponse: admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &statusErr.ErrStatus,
+		},
+	}
+}
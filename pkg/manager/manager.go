@@ -23,22 +23,42 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"k8c.io/utils/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
 	"k8c.io/bulward/pkg/manager/internal/controllers"
+	"k8c.io/bulward/pkg/manager/internal/webhooks"
+	"k8c.io/bulward/pkg/naming"
+	"k8c.io/bulward/pkg/templates"
 )
 
 type flags struct {
 	bulwardSystemNamespace string
+	serviceAccount         string
 	metricsAddr            string
 	healthAddr             string
 	enableLeaderElection   bool
+
+	namespacePrefix               string
+	organizationNamespaceTemplate string
+	projectNamespaceTemplate      string
+	namespaceHashLength           int
+
+	enableRoleRevocation bool
+	roleRevocationDryRun bool
+
+	defaultOrganizationRoleTemplatesDir string
+	disabledOrganizationRoleTemplates   []string
 }
 
 var (
@@ -47,6 +67,7 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(corev1alpha1.AddToScheme(scheme))
 	utilruntime.Must(storagev1alpha1.AddToScheme(scheme))
 }
@@ -71,6 +92,24 @@ func NewManagerCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&flags.enableLeaderElection, "enable-leader-election", true,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	cmd.Flags().StringVar(&flags.bulwardSystemNamespace, "bulward-system-namespace", os.Getenv("BULWARD_NAMESPACE"), "The namespace that Bulward controller manager deploys to.")
+	cmd.Flags().StringVar(&flags.serviceAccount, "service-account", os.Getenv("BULWARD_SERVICE_ACCOUNT"),
+		"The ServiceAccount that Bulward controller manager runs as, it's the only one allowed to change objects managed by Bulward.")
+	cmd.Flags().StringVar(&flags.namespacePrefix, "namespace-prefix", "", "Prefix of the namespaces created for Organizations and Projects.")
+	cmd.Flags().StringVar(&flags.organizationNamespaceTemplate, "organization-namespace-template", naming.DefaultOrganizationNamespaceTemplate,
+		"Go template of Organization namespace names, supports {{ .Name }}.")
+	cmd.Flags().StringVar(&flags.projectNamespaceTemplate, "project-namespace-template", naming.DefaultProjectNamespaceTemplate,
+		"Go template of Project namespace names, supports {{ .Name }} and {{ .Namespace }}.")
+	cmd.Flags().IntVar(&flags.namespaceHashLength, "namespace-hash-length", naming.DefaultHashLength,
+		"Length of the hash that is appended to namespace names to keep them unique.")
+	cmd.Flags().BoolVar(&flags.enableRoleRevocation, "enable-role-revocation", false,
+		"Revoke rules of user created Roles in Organization and Project namespaces that are not granted by any role template. Namespaces can override it with the bulward.io/role-revocation-mode label.")
+	cmd.Flags().BoolVar(&flags.roleRevocationDryRun, "role-revocation-dry-run", false,
+		"Only report rules that would be revoked via Events and annotations, without changing the Roles.")
+	cmd.Flags().StringVar(&flags.defaultOrganizationRoleTemplatesDir, "default-organization-role-templates-dir", "",
+		"Directory with YAML files of the default OrganizationRoleTemplates, e.g. a mounted ConfigMap. The built-in defaults are used, if not set.")
+	cmd.Flags().StringSliceVar(&flags.disabledOrganizationRoleTemplates, "disabled-default-organization-role-templates", nil,
+		"Names of default OrganizationRoleTemplates, that are not created and deleted if they are managed by Bulward.")
+	cmd.AddCommand(newPreviewCommand())
 	return util.CmdLogMixin(cmd)
 }
 
@@ -91,19 +130,39 @@ func run(flags *flags, log logr.Logger) error {
 	if flags.bulwardSystemNamespace == "" {
 		return fmt.Errorf("-bulward-system-namespace or ENVVAR BULWARD_NAMESPACE must be set")
 	}
+	if flags.serviceAccount == "" {
+		return fmt.Errorf("-service-account or ENVVAR BULWARD_SERVICE_ACCOUNT must be set")
+	}
+
+	namespacePolicy, err := naming.NewNamespacePolicy(
+		flags.namespacePrefix, flags.organizationNamespaceTemplate, flags.projectNamespaceTemplate, flags.namespaceHashLength)
+	if err != nil {
+		return fmt.Errorf("creating namespace naming policy: %w", err)
+	}
+
+	defaultOrganizationRoleTemplates, err := templates.DefaultOrganizationRoleTemplates(
+		flags.defaultOrganizationRoleTemplatesDir, flags.disabledOrganizationRoleTemplates)
+	if err != nil {
+		return fmt.Errorf("loading default OrganizationRoleTemplates: %w", err)
+	}
 
 	if err = (&controllers.OrganizationReconciler{
-		Client: mgr.GetClient(),
-		Log:    log.WithName("controllers").WithName("Organization"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      log.WithName("controllers").WithName("Organization"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("bulward-organization"),
+
+		NamespacePolicy:                  namespacePolicy,
+		DefaultOrganizationRoleTemplates: defaultOrganizationRoleTemplates,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("creating Organization controller: %w", err)
 	}
 
 	if err = (&controllers.OrganizationRoleTemplateReconciler{
-		Client: mgr.GetClient(),
-		Log:    log.WithName("controllers").WithName("OrganizationRoleTemplate"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      log.WithName("controllers").WithName("OrganizationRoleTemplate"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("bulward-organization-role-template"),
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("creating OrganizationRoleTemplate controller: %w", err)
 	}
@@ -112,18 +171,77 @@ func run(flags *flags, log logr.Logger) error {
 		Client: mgr.GetClient(),
 		Log:    log.WithName("controllers").WithName("Project"),
 		Scheme: mgr.GetScheme(),
+
+		NamespacePolicy: namespacePolicy,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("creating Project controller: %w", err)
 	}
 
 	if err = (&controllers.ProjectRoleTemplateReconciler{
-		Client: mgr.GetClient(),
-		Log:    log.WithName("controllers").WithName("ProjectRoleTemplate"),
-		Scheme: mgr.GetScheme(),
+		Client:   mgr.GetClient(),
+		Log:      log.WithName("controllers").WithName("ProjectRoleTemplate"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("bulward-project-role-template"),
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("creating ProjectRoleTemplate controller: %w", err)
 	}
 
+	if err = (&controllers.RoleRevocationReconciler{
+		Client:   mgr.GetClient(),
+		Log:      log.WithName("controllers").WithName("RoleRevocation"),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("bulward-role-revocation"),
+
+		Enabled: flags.enableRoleRevocation,
+		DryRun:  flags.roleRevocationDryRun,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("creating RoleRevocation controller: %w", err)
+	}
+
+	// Register webhooks as handlers
+	wbh := mgr.GetWebhookServer()
+	wbh.Register(
+		webhooks.GenerateValidateWebhookPath(&storagev1alpha1.Organization{}, mgr.GetScheme()),
+		&webhook.Admission{Handler: &webhooks.OrganizationWebhookHandler{
+			Reader: mgr.GetAPIReader(),
+			Log:    log.WithName("validating webhooks").WithName("Organization"),
+		}})
+	wbh.Register(
+		webhooks.GenerateValidateWebhookPath(&storagev1alpha1.Project{}, mgr.GetScheme()),
+		&webhook.Admission{Handler: &webhooks.ProjectWebhookHandler{
+			Reader: mgr.GetAPIReader(),
+			Log:    log.WithName("validating webhooks").WithName("Project"),
+		}})
+	wbh.Register(
+		webhooks.GenerateValidateWebhookPath(&corev1alpha1.OrganizationRoleTemplate{}, mgr.GetScheme()),
+		&webhook.Admission{Handler: &webhooks.OrganizationRoleTemplateWebhookHandler{
+			Log: log.WithName("validating webhooks").WithName("OrganizationRoleTemplate"),
+		}})
+	wbh.Register(
+		webhooks.GenerateValidateWebhookPath(&corev1alpha1.ProjectRoleTemplate{}, mgr.GetScheme()),
+		&webhook.Admission{Handler: &webhooks.ProjectRoleTemplateWebhookHandler{
+			Client: mgr.GetClient(),
+			Log:    log.WithName("validating webhooks").WithName("ProjectRoleTemplate"),
+		}})
+	wbh.Register(
+		webhooks.GenerateMutateWebhookPath(&corev1alpha1.OrganizationRoleTemplate{}, mgr.GetScheme()),
+		&webhook.Admission{Handler: &webhooks.OrganizationRoleTemplateDefaultingWebhookHandler{
+			Log: log.WithName("mutating webhooks").WithName("OrganizationRoleTemplate"),
+		}})
+	wbh.Register(
+		webhooks.GenerateMutateWebhookPath(&corev1alpha1.ProjectRoleTemplate{}, mgr.GetScheme()),
+		&webhook.Admission{Handler: &webhooks.ProjectRoleTemplateDefaultingWebhookHandler{
+			Log: log.WithName("mutating webhooks").WithName("ProjectRoleTemplate"),
+		}})
+
+	managedObjectWebhookHandler := &webhooks.ManagedObjectWebhookHandler{
+		ManagerUsername: serviceaccount.MakeUsername(flags.bulwardSystemNamespace, flags.serviceAccount),
+		Log:             log.WithName("validating webhooks").WithName("ManagedObject"),
+	}
+	for _, obj := range []runtime.Object{&rbacv1.Role{}, &rbacv1.RoleBinding{}, &corev1.Namespace{}} {
+		wbh.Register(webhooks.GenerateValidateWebhookPath(obj, mgr.GetScheme()), &webhook.Admission{Handler: managedObjectWebhookHandler})
+	}
+
 	if err := mgr.AddReadyzCheck("ping", healthz.Ping); err != nil {
 		return fmt.Errorf("adding readyz checker: %w", err)
 	}
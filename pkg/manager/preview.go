@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"k8c.io/bulward/pkg/templates"
+)
+
+const (
+	componentPreview = "preview"
+)
+
+// newPreviewCommand returns the command previewing the targets and RBAC changes of a role template without applying it.
+func newPreviewCommand() *cobra.Command {
+	return &cobra.Command{
+		Args:  cobra.ExactArgs(1),
+		Use:   componentPreview + " <file>",
+		Short: "preview the targets and RBAC changes of an OrganizationRoleTemplate or ProjectRoleTemplate, use - to read from stdin",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return preview(args[0])
+		},
+	}
+}
+
+func preview(file string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(file)
+	}
+	if err != nil {
+		return fmt.Errorf("reading role template: %w", err)
+	}
+	template, _, err := serializer.NewCodecFactory(scheme).UniversalDeserializer().Decode(data, nil, nil)
+	if err != nil {
+		return fmt.Errorf("decoding role template: %w", err)
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("getting kubeconfig: %w", err)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("creating client: %w", err)
+	}
+	status, err := templates.Preview(context.Background(), c, scheme, template)
+	if err != nil {
+		return fmt.Errorf("previewing role template: %w", err)
+	}
+	out, err := yaml.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshalling preview: %w", err)
+	}
+	_, err = os.Stdout.Write(out)
+	return err
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package naming
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+const (
+	// DefaultOrganizationNamespaceTemplate names Organization Namespaces after the Organization.
+	DefaultOrganizationNamespaceTemplate = "{{ .Name }}"
+	// DefaultProjectNamespaceTemplate names Project Namespaces after the Organization Namespace and the Project.
+	DefaultProjectNamespaceTemplate = "{{ .Namespace }}-{{ .Name }}"
+	// DefaultHashLength is the default number of hash characters appended to Namespace names.
+	DefaultHashLength = 8
+
+	minHashLength = 4
+	maxHashLength = 16
+)
+
+// NamespacePolicy builds the Namespace names of Organizations and Projects.
+// A name consists of the prefix and the rendered template, truncated to fit into a DNS label,
+// followed by a hash of the owning object, so names stay unique regardless of the template.
+type NamespacePolicy struct {
+	prefix               string
+	organizationTemplate *template.Template
+	projectTemplate      *template.Template
+	hashLength           int
+}
+
+// NamespaceTemplateData is passed to the Namespace name templates.
+type NamespaceTemplateData struct {
+	// Name is the user facing name of the Organization or Project.
+	Name string
+	// Namespace is the Namespace of the Project, empty for Organizations.
+	Namespace string
+}
+
+// NewNamespacePolicy parses the given templates and returns a new NamespacePolicy.
+func NewNamespacePolicy(prefix, organizationTemplate, projectTemplate string, hashLength int) (*NamespacePolicy, error) {
+	if hashLength < minHashLength || hashLength > maxHashLength {
+		return nil, fmt.Errorf("hash length must be between %d and %d, got %d", minHashLength, maxHashLength, hashLength)
+	}
+	if errs := validation.IsDNS1123Label(strings.TrimSuffix(prefix, "-")); prefix != "" && len(errs) > 0 {
+		return nil, fmt.Errorf("invalid prefix %q: %s", prefix, strings.Join(errs, ", "))
+	}
+	orgTmpl, err := template.New("organization").Option("missingkey=error").Parse(organizationTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Organization namespace template: %w", err)
+	}
+	projectTmpl, err := template.New("project").Option("missingkey=error").Parse(projectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Project namespace template: %w", err)
+	}
+	return &NamespacePolicy{
+		prefix:               prefix,
+		organizationTemplate: orgTmpl,
+		projectTemplate:      projectTmpl,
+		hashLength:           hashLength,
+	}, nil
+}
+
+// DefaultNamespacePolicy returns the NamespacePolicy that is used, if nothing else is configured.
+func DefaultNamespacePolicy() *NamespacePolicy {
+	policy, err := NewNamespacePolicy("", DefaultOrganizationNamespaceTemplate, DefaultProjectNamespaceTemplate, DefaultHashLength)
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
+
+// OrganizationNamespace returns the Namespace name for the given Organization.
+func (p *NamespacePolicy) OrganizationNamespace(organization *storagev1alpha1.Organization) (string, error) {
+	return p.render(p.organizationTemplate, NamespaceTemplateData{
+		Name: organization.Alias(),
+	}, "Organization/"+organization.Name)
+}
+
+// ProjectNamespace returns the Namespace name for the given Project.
+func (p *NamespacePolicy) ProjectNamespace(project *storagev1alpha1.Project) (string, error) {
+	return p.render(p.projectTemplate, NamespaceTemplateData{
+		Name:      project.Name,
+		Namespace: project.Namespace,
+	}, "Project/"+project.Namespace+"/"+project.Name)
+}
+
+// render renders the template and appends the hash of the given key, which needs to identify the owning object.
+func (p *NamespacePolicy) render(tmpl *template.Template, data NamespaceTemplateData, key string) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("rendering %s namespace template: %w", tmpl.Name(), err)
+	}
+	hash := sha256.Sum256([]byte(key))
+	suffix := hex.EncodeToString(hash[:])[:p.hashLength]
+
+	base := sanitize(p.prefix + sb.String())
+	if maxBaseLength := validation.DNS1123LabelMaxLength - len(suffix) - 1; len(base) > maxBaseLength {
+		base = strings.TrimRight(base[:maxBaseLength], "-")
+	}
+	if base == "" {
+		return suffix, nil
+	}
+	return base + "-" + suffix, nil
+}
+
+// sanitize turns the given string into a valid DNS label prefix.
+func sanitize(s string) string {
+	s = strings.ToLower(s)
+	s = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '-'
+	}, s)
+	return strings.Trim(s, "-")
+}
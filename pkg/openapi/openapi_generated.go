@@ -1,3 +1,4 @@
+//go:build !ignore_autogenerated
 // +build !ignore_autogenerated
 
 /*
@@ -36,6 +37,15 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"k8c.io/bulward/pkg/apis/apiserver/v1alpha1.OrganizationList":    schema_pkg_apis_apiserver_v1alpha1_OrganizationList(ref),
 		"k8c.io/bulward/pkg/apis/apiserver/v1alpha1.Project":             schema_pkg_apis_apiserver_v1alpha1_Project(ref),
 		"k8c.io/bulward/pkg/apis/apiserver/v1alpha1.ProjectList":         schema_pkg_apis_apiserver_v1alpha1_ProjectList(ref),
+		"k8c.io/bulward/pkg/apis/apiserver/v1alpha1.TemplatePreview":     schema_pkg_apis_apiserver_v1alpha1_TemplatePreview(ref),
+		"k8c.io/bulward/pkg/apis/apiserver/v1alpha1.TemplatePreviewList": schema_pkg_apis_apiserver_v1alpha1_TemplatePreviewList(ref),
+		"k8c.io/bulward/pkg/apis/core/v1alpha1.RBACChange":               schema_pkg_apis_core_v1alpha1_RBACChange(ref),
+		"k8c.io/bulward/pkg/apis/core/v1alpha1.RBACObjectState":          schema_pkg_apis_core_v1alpha1_RBACObjectState(ref),
+		"k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewSpec":      schema_pkg_apis_core_v1alpha1_TemplatePreviewSpec(ref),
+		"k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewStatus":    schema_pkg_apis_core_v1alpha1_TemplatePreviewStatus(ref),
+		"k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewTarget":    schema_pkg_apis_core_v1alpha1_TemplatePreviewTarget(ref),
+		"k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberProvenance":      schema_pkg_apis_storage_v1alpha1_MemberProvenance(ref),
+		"k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberSource":          schema_pkg_apis_storage_v1alpha1_MemberSource(ref),
 		"k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference":       schema_pkg_apis_storage_v1alpha1_ObjectReference(ref),
 		"k8c.io/bulward/pkg/apis/storage/v1alpha1.Organization":          schema_pkg_apis_storage_v1alpha1_Organization(ref),
 		"k8c.io/bulward/pkg/apis/storage/v1alpha1.OrganizationCondition": schema_pkg_apis_storage_v1alpha1_OrganizationCondition(ref),
@@ -528,6 +538,69 @@ func schema_pkg_apis_apiserver_v1alpha1_ProjectList(ref common.ReferenceCallback
 	}
 }
 
+func schema_pkg_apis_storage_v1alpha1_MemberProvenance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemberProvenance lists all objects granting a subject its membership. A subject stays a member, as long as any of its sources exist.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"subject": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subject is the member.",
+							Ref:         ref("k8s.io/api/rbac/v1.Subject"),
+						},
+					},
+					"sources": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sources are the objects granting the membership.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberSource"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"subject", "sources"},
+			},
+		},
+		Dependencies: []string{
+			"k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberSource", "k8s.io/api/rbac/v1.Subject"},
+	}
+}
+
+func schema_pkg_apis_storage_v1alpha1_MemberSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MemberSource references the object granting a subject its membership.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the object granting the membership, one of ('Owner', 'RoleBinding', 'Project').",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the object granting the membership, empty for owners.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"kind"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_storage_v1alpha1_ObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -592,6 +665,311 @@ func schema_pkg_apis_storage_v1alpha1_Organization(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_apiserver_v1alpha1_TemplatePreview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplatePreview previews the targets and RBAC changes of an OrganizationRoleTemplate or ProjectRoleTemplate without applying it. TemplatePreviews are not stored, the preview is returned in the status of the created object.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewSpec", "k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_apiserver_v1alpha1_TemplatePreviewList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/apiserver/v1alpha1.TemplatePreview"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"items"},
+			},
+		},
+		Dependencies: []string{
+			"k8c.io/bulward/pkg/apis/apiserver/v1alpha1.TemplatePreview", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_RBACChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RBACChange describes a change to a single Role or RoleBinding.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type of the change.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the changed object, \"Role\" or \"RoleBinding\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the changed object.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the changed object.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"current": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Current state of the object, unset if it would be created.",
+							Ref:         ref("k8c.io/bulward/pkg/apis/core/v1alpha1.RBACObjectState"),
+						},
+					},
+					"desired": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Desired state of the object, unset if it would be deleted.",
+							Ref:         ref("k8c.io/bulward/pkg/apis/core/v1alpha1.RBACObjectState"),
+						},
+					},
+				},
+				Required: []string{"type", "kind", "namespace", "name"},
+			},
+		},
+		Dependencies: []string{
+			"k8c.io/bulward/pkg/apis/core/v1alpha1.RBACObjectState"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_RBACObjectState(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RBACObjectState holds the relevant parts of a Role or RoleBinding.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"rules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rules of a Role.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/rbac/v1.PolicyRule"),
+									},
+								},
+							},
+						},
+					},
+					"roleRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RoleRef of a RoleBinding.",
+							Ref:         ref("k8s.io/api/rbac/v1.RoleRef"),
+						},
+					},
+					"subjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Subjects of a RoleBinding.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8s.io/api/rbac/v1.Subject"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/rbac/v1.PolicyRule", "k8s.io/api/rbac/v1.RoleRef", "k8s.io/api/rbac/v1.Subject"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_TemplatePreviewSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplatePreviewSpec describes the role template to preview.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template is the OrganizationRoleTemplate or ProjectRoleTemplate to preview, as it would be applied.",
+							Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+						},
+					},
+				},
+				Required: []string{"template"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_TemplatePreviewStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplatePreviewStatus describes the targets of the previewed role template and the changes to its Roles and RoleBindings, that applying the template would cause.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"targets": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Targets are the Organizations and Projects the role template would be rolled out to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewTarget"),
+									},
+								},
+							},
+						},
+					},
+					"changes": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Changes are the Roles and RoleBindings that would be created, updated or deleted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/core/v1alpha1.RBACChange"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8c.io/bulward/pkg/apis/core/v1alpha1.RBACChange", "k8c.io/bulward/pkg/apis/core/v1alpha1.TemplatePreviewTarget"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_TemplatePreviewTarget(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplatePreviewTarget is an Organization or Project the previewed role template would be rolled out to.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind of the target, \"Organization\" or \"Project\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the target.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"organization": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Organization the target belongs to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace of the target, the Role and RoleBinding are created in.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message is the human readable message indicating why the role template can't be rendered for this target. Existing Roles and RoleBindings of the role template in the namespace are kept in this case.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"kind", "name", "namespace"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_storage_v1alpha1_OrganizationCondition(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -601,7 +979,7 @@ func schema_pkg_apis_storage_v1alpha1_OrganizationCondition(ref common.Reference
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type is the type of the Organization condition, currently ('Ready').",
+							Description: "Type is the type of the Organization condition, currently ('Ready', 'NamespaceConflict').",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -743,12 +1121,25 @@ func schema_pkg_apis_storage_v1alpha1_OrganizationSpec(ref common.ReferenceCallb
 							},
 						},
 					},
+					"excludedOrganizationRoleTemplates": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludedOrganizationRoleTemplates lists OrganizationRoleTemplates that are not applied to this Organization and its Projects.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"owners"},
 			},
 		},
 		Dependencies: []string{
-			"k8c.io/bulward/pkg/apis/storage/v1alpha1.OrganizationMetadata", "k8s.io/api/rbac/v1.Subject"},
+			"k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference", "k8c.io/bulward/pkg/apis/storage/v1alpha1.OrganizationMetadata", "k8s.io/api/rbac/v1.Subject"},
 	}
 }
 
@@ -805,11 +1196,24 @@ func schema_pkg_apis_storage_v1alpha1_OrganizationStatus(ref common.ReferenceCal
 							},
 						},
 					},
+					"memberProvenance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemberProvenance records the owners, RoleBindings and Projects that grant each of the Members.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberProvenance"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference", "k8c.io/bulward/pkg/apis/storage/v1alpha1.OrganizationCondition", "k8s.io/api/rbac/v1.Subject"},
+			"k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberProvenance", "k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference", "k8c.io/bulward/pkg/apis/storage/v1alpha1.OrganizationCondition", "k8s.io/api/rbac/v1.Subject"},
 	}
 }
 
@@ -866,7 +1270,7 @@ func schema_pkg_apis_storage_v1alpha1_ProjectCondition(ref common.ReferenceCallb
 				Properties: map[string]spec.Schema{
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type is the type of the Project condition, currently ('Ready').",
+							Description: "Type is the type of the Project condition, currently ('Ready', 'NamespaceConflict').",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1036,11 +1440,24 @@ func schema_pkg_apis_storage_v1alpha1_ProjectStatus(ref common.ReferenceCallback
 							},
 						},
 					},
+					"memberProvenance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MemberProvenance records the owners and RoleBindings that grant each of the Members.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberProvenance"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference", "k8c.io/bulward/pkg/apis/storage/v1alpha1.ProjectCondition", "k8s.io/api/rbac/v1.Subject"},
+			"k8c.io/bulward/pkg/apis/storage/v1alpha1.MemberProvenance", "k8c.io/bulward/pkg/apis/storage/v1alpha1.ObjectReference", "k8c.io/bulward/pkg/apis/storage/v1alpha1.ProjectCondition", "k8s.io/api/rbac/v1.Subject"},
 	}
 }
 
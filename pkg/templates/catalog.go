@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// CatalogVersion is the version of the built-in role catalog.
+// It has to be increased on every change of the catalog, so existing catalog templates are updated on upgrades.
+const CatalogVersion = "2"
+
+// Names of the OrganizationRoleTemplates of the built-in role catalog.
+const (
+	OrgViewerOrganizationRoleTemplateName     = "bulward-org-viewer"
+	OrgAdminOrganizationRoleTemplateName      = "bulward-org-admin"
+	ProjectViewerOrganizationRoleTemplateName = "bulward-project-viewer"
+	ProjectEditorOrganizationRoleTemplateName = "bulward-project-editor"
+	ProjectAdminCatalogRoleTemplateName       = "bulward-project-admin"
+)
+
+// Role names of the built-in role catalog. They are stable across catalog versions, so integrators can bind users to them.
+const (
+	OrgViewerRoleName     = "bulward:org-viewer"
+	OrgAdminRoleName      = "bulward:org-admin"
+	ProjectViewerRoleName = "bulward:project-viewer"
+	ProjectEditorRoleName = "bulward:project-editor"
+	ProjectAdminRoleName  = "bulward:project-admin"
+)
+
+// CatalogOrganizationRoleTemplates returns the built-in role catalog.
+// The tiers copy the rules of the Kubernetes view, edit and admin ClusterRoles,
+// so they also cover custom resources aggregated to these ClusterRoles.
+// Viewers are bound to everyone, editors and admins to the Organization owners.
+// Integrators can bind further subjects to the Roles, as their names are stable.
+func CatalogOrganizationRoleTemplates() []*corev1alpha1.OrganizationRoleTemplate {
+	return []*corev1alpha1.OrganizationRoleTemplate{
+		catalogOrganizationRoleTemplate(OrgViewerOrganizationRoleTemplateName, OrgViewerRoleName, "Organization Viewer",
+			"Read access to the Organization namespace.",
+			corev1alpha1.RoleTemplateScopeOrganization, "view", corev1alpha1.BindToEveryone),
+		catalogOrganizationRoleTemplate(OrgAdminOrganizationRoleTemplateName, OrgAdminRoleName, "Organization Admin",
+			"Full access to the Organization namespace, including Roles and RoleBindings.",
+			corev1alpha1.RoleTemplateScopeOrganization, "admin", corev1alpha1.BindToOwners),
+		catalogOrganizationRoleTemplate(ProjectViewerOrganizationRoleTemplateName, ProjectViewerRoleName, "Project Viewer",
+			"Read access to Project namespaces.",
+			corev1alpha1.RoleTemplateScopeProject, "view", corev1alpha1.BindToEveryone),
+		catalogOrganizationRoleTemplate(ProjectEditorOrganizationRoleTemplateName, ProjectEditorRoleName, "Project Editor",
+			"Read and write access to the workloads of Project namespaces.",
+			corev1alpha1.RoleTemplateScopeProject, "edit", corev1alpha1.BindToOwners),
+		catalogOrganizationRoleTemplate(ProjectAdminCatalogRoleTemplateName, ProjectAdminRoleName, "Project Admin",
+			"Full access to Project namespaces, including Roles and RoleBindings.",
+			corev1alpha1.RoleTemplateScopeProject, "admin", corev1alpha1.BindToOwners),
+	}
+}
+
+func catalogOrganizationRoleTemplate(
+	name, roleName, displayName, description string,
+	scope corev1alpha1.RoleTemplateScope, clusterRole string, bindTo corev1alpha1.BindingType,
+) *corev1alpha1.OrganizationRoleTemplate {
+	return &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				corev1alpha1.RoleTemplateVersionAnnotation: CatalogVersion,
+			},
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Metadata: &corev1alpha1.OrganizationRoleTemplateMetadata{
+				DisplayName: displayName,
+				Description: description,
+			},
+			Scopes:         []corev1alpha1.RoleTemplateScope{scope},
+			BindTo:         []corev1alpha1.BindingType{bindTo},
+			RoleName:       roleName,
+			ClusterRoleRef: &corev1alpha1.ObjectReference{Name: clusterRole},
+		},
+	}
+}
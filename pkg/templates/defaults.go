@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// DefaultOrganizationRoleTemplates returns the default set of OrganizationRoleTemplates without the disabled ones.
+// The set is loaded from the directory, if it's not empty,
+// and consists of the built-in defaults for Organization owners and the built-in role catalog otherwise.
+func DefaultOrganizationRoleTemplates(dir string, disabled []string) ([]*corev1alpha1.OrganizationRoleTemplate, error) {
+	defaults := append(DefaultOrganizationRoleTemplatesForOwners(), CatalogOrganizationRoleTemplates()...)
+	if dir != "" {
+		var err error
+		defaults, err = LoadOrganizationRoleTemplates(dir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	disabledNames := map[string]bool{}
+	for _, name := range disabled {
+		disabledNames[name] = true
+	}
+	var enabled []*corev1alpha1.OrganizationRoleTemplate
+	for _, template := range defaults {
+		if disabledNames[template.Name] {
+			continue
+		}
+		enabled = append(enabled, template)
+	}
+	return enabled, nil
+}
+
+// LoadOrganizationRoleTemplates reads the OrganizationRoleTemplates from the YAML and JSON files in the directory.
+// Files may contain multiple documents. Hidden files and directories, like the ones of mounted ConfigMaps, are skipped.
+func LoadOrganizationRoleTemplates(dir string) ([]*corev1alpha1.OrganizationRoleTemplate, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading directory: %w", err)
+	}
+
+	names := map[string]string{}
+	var templates []*corev1alpha1.OrganizationRoleTemplate
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") || file.IsDir() {
+			continue
+		}
+		switch filepath.Ext(file.Name()) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		loaded, err := loadOrganizationRoleTemplateFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", path, err)
+		}
+		for _, template := range loaded {
+			if other, ok := names[template.Name]; ok {
+				return nil, fmt.Errorf("OrganizationRoleTemplate %s is defined in %s and %s", template.Name, other, path)
+			}
+			names[template.Name] = path
+			templates = append(templates, template)
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+func loadOrganizationRoleTemplateFile(path string) ([]*corev1alpha1.OrganizationRoleTemplate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var templates []*corev1alpha1.OrganizationRoleTemplate
+	decoder := utilyaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		template := &corev1alpha1.OrganizationRoleTemplate{}
+		if err := decoder.Decode(template); errors.Is(err, io.EOF) {
+			return templates, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("decoding: %w", err)
+		}
+		if template.Kind == "" && template.Name == "" {
+			// empty document
+			continue
+		}
+		if template.Kind != "OrganizationRoleTemplate" {
+			return nil, fmt.Errorf("unexpected kind %q, only OrganizationRoleTemplates are supported", template.Kind)
+		}
+		if template.Name == "" {
+			return nil, fmt.Errorf("OrganizationRoleTemplate without name")
+		}
+		templates = append(templates, template)
+	}
+}
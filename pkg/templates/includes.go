@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// Kinds of role templates, that can be included.
+const (
+	OrganizationRoleTemplateKind = "OrganizationRoleTemplate"
+	ProjectRoleTemplateKind      = "ProjectRoleTemplate"
+)
+
+// TemplateKey identifies a role template. The Namespace is empty for OrganizationRoleTemplates.
+type TemplateKey struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// OrganizationRoleTemplateKey returns the key of the OrganizationRoleTemplate.
+func OrganizationRoleTemplateKey(template *corev1alpha1.OrganizationRoleTemplate) TemplateKey {
+	return TemplateKey{Kind: OrganizationRoleTemplateKind, Name: template.Name}
+}
+
+// ProjectRoleTemplateKey returns the key of the ProjectRoleTemplate.
+func ProjectRoleTemplateKey(template *corev1alpha1.ProjectRoleTemplate) TemplateKey {
+	return TemplateKey{Kind: ProjectRoleTemplateKind, Namespace: template.Namespace, Name: template.Name}
+}
+
+func (k TemplateKey) String() string {
+	if k.Namespace == "" {
+		return k.Kind + " " + k.Name
+	}
+	return k.Kind + " " + k.Namespace + "/" + k.Name
+}
+
+// include returns the key of the role template included by the reference.
+func (k TemplateKey) include(ref corev1alpha1.RoleTemplateReference) (TemplateKey, error) {
+	switch ref.Kind {
+	case "", OrganizationRoleTemplateKind:
+		return TemplateKey{Kind: OrganizationRoleTemplateKind, Name: ref.Name}, nil
+	case ProjectRoleTemplateKind:
+		if k.Kind != ProjectRoleTemplateKind {
+			return TemplateKey{}, fmt.Errorf("%s can't include ProjectRoleTemplate %s", k, ref.Name)
+		}
+		return TemplateKey{Kind: ProjectRoleTemplateKind, Namespace: k.Namespace, Name: ref.Name}, nil
+	}
+	return TemplateKey{}, fmt.Errorf("unsupported role template kind %q", ref.Kind)
+}
+
+// IncludeCycleError is returned, when included role templates include each other.
+type IncludeCycleError struct {
+	// Cycle lists the role templates of the cycle, starting and ending with the same template.
+	Cycle []TemplateKey
+}
+
+func (e *IncludeCycleError) Error() string {
+	var cycle []string
+	for _, key := range e.Cycle {
+		cycle = append(cycle, key.String())
+	}
+	return "include cycle: " + strings.Join(cycle, " -> ")
+}
+
+// IncludedTemplate is a role template, that is directly or transitively included by another role template.
+type IncludedTemplate struct {
+	TemplateKey
+	Rules          []rbacv1.PolicyRule
+	ClusterRoleRef *corev1alpha1.ObjectReference
+	CRDRules       []corev1alpha1.RoleTemplateCRDRules
+}
+
+// ResolveIncludes returns the role templates directly and transitively included by the role template, every template once.
+// An *IncludeCycleError is returned, if the included role templates include each other.
+func ResolveIncludes(ctx context.Context, c client.Reader, key TemplateKey, includes []corev1alpha1.RoleTemplateReference) ([]IncludedTemplate, error) {
+	r := &includeResolver{c: c, visited: map[TemplateKey]bool{}}
+	if err := r.resolve(ctx, key, includes); err != nil {
+		return nil, err
+	}
+	return r.included, nil
+}
+
+type includeResolver struct {
+	c client.Reader
+	// path is the chain of role templates, that led to the current one.
+	path     []TemplateKey
+	visited  map[TemplateKey]bool
+	included []IncludedTemplate
+}
+
+func (r *includeResolver) resolve(ctx context.Context, key TemplateKey, includes []corev1alpha1.RoleTemplateReference) error {
+	r.path = append(r.path, key)
+	defer func() { r.path = r.path[:len(r.path)-1] }()
+
+	for _, ref := range includes {
+		includedKey, err := key.include(ref)
+		if err != nil {
+			return err
+		}
+		for i, k := range r.path {
+			if k == includedKey {
+				cycle := append([]TemplateKey{}, r.path[i:]...)
+				return &IncludeCycleError{Cycle: append(cycle, includedKey)}
+			}
+		}
+		if r.visited[includedKey] {
+			// Templates included several times contribute their rules only once.
+			continue
+		}
+		r.visited[includedKey] = true
+
+		included, nestedIncludes, err := r.get(ctx, includedKey)
+		if err != nil {
+			return err
+		}
+		r.included = append(r.included, included)
+		if err := r.resolve(ctx, includedKey, nestedIncludes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *includeResolver) get(ctx context.Context, key TemplateKey) (IncludedTemplate, []corev1alpha1.RoleTemplateReference, error) {
+	included := IncludedTemplate{TemplateKey: key}
+	nn := types.NamespacedName{Name: key.Name, Namespace: key.Namespace}
+	if key.Kind == ProjectRoleTemplateKind {
+		template := &corev1alpha1.ProjectRoleTemplate{}
+		if err := r.c.Get(ctx, nn, template); err != nil {
+			return included, nil, fmt.Errorf("getting included %s: %w", key, err)
+		}
+		included.Rules = template.Spec.Rules
+		included.ClusterRoleRef = template.Spec.ClusterRoleRef
+		included.CRDRules = template.Spec.CRDRules
+		return included, template.Spec.Includes, nil
+	}
+	template := &corev1alpha1.OrganizationRoleTemplate{}
+	if err := r.c.Get(ctx, nn, template); err != nil {
+		return included, nil, fmt.Errorf("getting included %s: %w", key, err)
+	}
+	included.Rules = template.Spec.Rules
+	included.ClusterRoleRef = template.Spec.ClusterRoleRef
+	included.CRDRules = template.Spec.CRDRules
+	return included, template.Spec.Includes, nil
+}
+
+// includedRules returns the rules of the included role templates.
+// The own rules of the included templates are returned unrendered, so placeholders are rendered for the targets of the including template.
+// Rules of referenced ClusterRoles and generated rules are returned as referenced rules.
+func includedRules(ctx context.Context, c client.Reader, key TemplateKey, includes []corev1alpha1.RoleTemplateReference) (rules, referencedRules []rbacv1.PolicyRule, err error) {
+	included, err := ResolveIncludes(ctx, c, key, includes)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, template := range included {
+		rules = append(rules, withoutForbiddenVerbs(template.Rules)...)
+		referenced, err := ReferencedRules(ctx, c, template.ClusterRoleRef, template.CRDRules)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving rules of included %s: %w", template.TemplateKey, err)
+		}
+		referencedRules = append(referencedRules, referenced...)
+	}
+	return rules, referencedRules, nil
+}
+
+// IncludeGraph maps role templates to the role templates they include.
+type IncludeGraph map[TemplateKey][]TemplateKey
+
+// AddOrganizationRoleTemplate adds the includes of the OrganizationRoleTemplate to the graph.
+func (g IncludeGraph) AddOrganizationRoleTemplate(template *corev1alpha1.OrganizationRoleTemplate) {
+	g.add(OrganizationRoleTemplateKey(template), template.Spec.Includes)
+}
+
+// AddProjectRoleTemplate adds the includes of the ProjectRoleTemplate to the graph.
+func (g IncludeGraph) AddProjectRoleTemplate(template *corev1alpha1.ProjectRoleTemplate) {
+	g.add(ProjectRoleTemplateKey(template), template.Spec.Includes)
+}
+
+func (g IncludeGraph) add(key TemplateKey, includes []corev1alpha1.RoleTemplateReference) {
+	for _, ref := range includes {
+		includedKey, err := key.include(ref)
+		if err != nil {
+			// Invalid includes fail the rollout of the template, they don't need to be tracked.
+			continue
+		}
+		g[key] = append(g[key], includedKey)
+	}
+}
+
+// Includers returns the role templates, that directly or transitively include the role template.
+func (g IncludeGraph) Includers(key TemplateKey) []TemplateKey {
+	includers := map[TemplateKey][]TemplateKey{}
+	for includer, includes := range g {
+		for _, included := range includes {
+			includers[included] = append(includers[included], includer)
+		}
+	}
+
+	var out []TemplateKey
+	seen := map[TemplateKey]bool{key: true}
+	queue := []TemplateKey{key}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, includer := range includers[current] {
+			if seen[includer] {
+				continue
+			}
+			seen[includer] = true
+			out = append(out, includer)
+			queue = append(queue, includer)
+		}
+	}
+	return out
+}
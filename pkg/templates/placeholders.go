@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// PlaceholderData holds the values the placeholders of role templates are rendered with for a single target,
+// e.g. `{{ .Organization.Name }}`, `{{ .Project.Name }}` or `{{ .Namespace }}`.
+type PlaceholderData struct {
+	// Organization of the target.
+	Organization PlaceholderObject
+	// Project of the target, empty if the target is an Organization.
+	Project PlaceholderObject
+	// Namespace the Role and RoleBinding of the target are created in.
+	Namespace string
+}
+
+// PlaceholderObject describes an Organization or Project for placeholders.
+type PlaceholderObject struct {
+	// Name of the Organization or Project, as seen by users of the apiserver.
+	Name string
+	// Namespace is the Namespace Bulward manages for the Organization or Project.
+	Namespace string
+}
+
+// examplePlaceholderData is used to check placeholders, before there is an actual target.
+var examplePlaceholderData = PlaceholderData{
+	Organization: PlaceholderObject{Name: "organization", Namespace: "organization-namespace"},
+	Project:      PlaceholderObject{Name: "project", Namespace: "project-namespace"},
+	Namespace:    "namespace",
+}
+
+// HasPlaceholders returns true, if the given string contains placeholders.
+func HasPlaceholders(s string) bool {
+	return strings.Contains(s, "{{")
+}
+
+// ValidatePlaceholders checks that the placeholders of the given string can be rendered.
+func ValidatePlaceholders(s string) error {
+	_, err := RenderPlaceholders(s, examplePlaceholderData)
+	return err
+}
+
+// RenderPlaceholders renders the placeholders of the given string.
+// Placeholders rendering to an empty string, like `{{ .Project.Name }}` for an Organization, are an error.
+func RenderPlaceholders(s string, data PlaceholderData) (string, error) {
+	if !HasPlaceholders(s) {
+		return s, nil
+	}
+	tmpl, err := template.New("placeholder").Option("missingkey=error").Parse(s)
+	if err != nil {
+		return "", fmt.Errorf("parsing placeholders of %q: %w", s, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("rendering placeholders of %q: %w", s, err)
+	}
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("placeholders of %q rendered to an empty string", s)
+	}
+	return sb.String(), nil
+}
+
+// RenderRules returns a copy of the rules with the placeholders of their ResourceNames rendered.
+func RenderRules(rules []rbacv1.PolicyRule, data PlaceholderData) ([]rbacv1.PolicyRule, error) {
+	rendered := make([]rbacv1.PolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		rule := *rule.DeepCopy()
+		for i, resourceName := range rule.ResourceNames {
+			name, err := RenderPlaceholders(resourceName, data)
+			if err != nil {
+				return nil, err
+			}
+			rule.ResourceNames[i] = name
+		}
+		rendered = append(rendered, rule)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"context"
+	"fmt"
+
+	"k8c.io/utils/pkg/owner"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+)
+
+// Preview computes the targets of the OrganizationRoleTemplate or ProjectRoleTemplate and the changes to its Roles and RoleBindings,
+// that applying the template would cause. Nothing is written to the cluster.
+// Staged rollouts are not taken into account, the preview shows the state after the rollout completed.
+func Preview(ctx context.Context, c client.Reader, scheme *runtime.Scheme, template runtime.Object) (*corev1alpha1.TemplatePreviewStatus, error) {
+	var (
+		targets []Target
+		err     error
+	)
+	switch t := template.(type) {
+	case *corev1alpha1.OrganizationRoleTemplate:
+		targets, _, err = OrganizationRoleTemplateTargets(ctx, c, t)
+	case *corev1alpha1.ProjectRoleTemplate:
+		targets, err = ProjectRoleTemplateTargets(ctx, c, t)
+	default:
+		return nil, fmt.Errorf("unsupported role template type %T", template)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Existing objects are found via the owner labels, so a template that doesn't exist yet only creates objects.
+	roles := &rbacv1.RoleList{}
+	if err := c.List(ctx, roles, owner.OwnedBy(template, scheme)); err != nil {
+		return nil, fmt.Errorf("listing Roles: %w", err)
+	}
+	currentRoles := map[types.NamespacedName]*rbacv1.Role{}
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		currentRoles[types.NamespacedName{Name: role.Name, Namespace: role.Namespace}] = role
+	}
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := c.List(ctx, roleBindings, owner.OwnedBy(template, scheme)); err != nil {
+		return nil, fmt.Errorf("listing RoleBindings: %w", err)
+	}
+	currentRoleBindings := map[types.NamespacedName]*rbacv1.RoleBinding{}
+	for i := range roleBindings.Items {
+		roleBinding := &roleBindings.Items[i]
+		currentRoleBindings[types.NamespacedName{Name: roleBinding.Name, Namespace: roleBinding.Namespace}] = roleBinding
+	}
+
+	status := &corev1alpha1.TemplatePreviewStatus{}
+	desiredRoles := map[types.NamespacedName]bool{}
+	desiredRoleBindings := map[types.NamespacedName]bool{}
+	keptNamespaces := map[string]bool{}
+	for _, target := range targets {
+		previewTarget := corev1alpha1.TemplatePreviewTarget{
+			Kind:         target.Kind,
+			Name:         target.Name,
+			Organization: target.Organization,
+			Namespace:    target.Namespace,
+		}
+		if target.Err != nil {
+			// Like the controllers, existing objects are kept for targets that can't be rendered.
+			previewTarget.Message = target.Err.Error()
+			status.Targets = append(status.Targets, previewTarget)
+			keptNamespaces[target.Namespace] = true
+			continue
+		}
+		status.Targets = append(status.Targets, previewTarget)
+
+		key := types.NamespacedName{Name: target.Role.Name, Namespace: target.Role.Namespace}
+		desiredRoles[key] = true
+		desired := roleState(target.Role)
+		if current, ok := currentRoles[key]; !ok {
+			status.Changes = append(status.Changes, rbacChange(corev1alpha1.RBACChangeCreate, "Role", key, nil, desired))
+		} else if currentState := roleState(current); !equality.Semantic.DeepEqual(currentState, desired) {
+			status.Changes = append(status.Changes, rbacChange(corev1alpha1.RBACChangeUpdate, "Role", key, currentState, desired))
+		}
+
+		if target.RoleBinding == nil {
+			continue
+		}
+		key = types.NamespacedName{Name: target.RoleBinding.Name, Namespace: target.RoleBinding.Namespace}
+		desiredRoleBindings[key] = true
+		desired = roleBindingState(target.RoleBinding)
+		if current, ok := currentRoleBindings[key]; !ok {
+			status.Changes = append(status.Changes, rbacChange(corev1alpha1.RBACChangeCreate, "RoleBinding", key, nil, desired))
+		} else if currentState := roleBindingState(current); !equality.Semantic.DeepEqual(currentState, desired) {
+			status.Changes = append(status.Changes, rbacChange(corev1alpha1.RBACChangeUpdate, "RoleBinding", key, currentState, desired))
+		}
+	}
+
+	// Objects of targets that left the scope of the template are pruned.
+	for _, role := range roles.Items {
+		key := types.NamespacedName{Name: role.Name, Namespace: role.Namespace}
+		if keptNamespaces[role.Namespace] || desiredRoles[key] {
+			continue
+		}
+		status.Changes = append(status.Changes, rbacChange(corev1alpha1.RBACChangeDelete, "Role", key, roleState(&role), nil))
+	}
+	for _, roleBinding := range roleBindings.Items {
+		key := types.NamespacedName{Name: roleBinding.Name, Namespace: roleBinding.Namespace}
+		if keptNamespaces[roleBinding.Namespace] || desiredRoleBindings[key] {
+			continue
+		}
+		status.Changes = append(status.Changes, rbacChange(corev1alpha1.RBACChangeDelete, "RoleBinding", key, roleBindingState(&roleBinding), nil))
+	}
+	return status, nil
+}
+
+func rbacChange(changeType corev1alpha1.RBACChangeType, kind string, key types.NamespacedName, current, desired *corev1alpha1.RBACObjectState) corev1alpha1.RBACChange {
+	return corev1alpha1.RBACChange{
+		Type:      changeType,
+		Kind:      kind,
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		Current:   current,
+		Desired:   desired,
+	}
+}
+
+func roleState(role *rbacv1.Role) *corev1alpha1.RBACObjectState {
+	return &corev1alpha1.RBACObjectState{Rules: role.Rules}
+}
+
+func roleBindingState(roleBinding *rbacv1.RoleBinding) *corev1alpha1.RBACObjectState {
+	roleRef := roleBinding.RoleRef
+	return &corev1alpha1.RBACObjectState{
+		RoleRef:  &roleRef,
+		Subjects: roleBinding.Subjects,
+	}
+}
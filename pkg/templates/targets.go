@@ -0,0 +1,451 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8c.io/utils/pkg/owner"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+// Target holds the desired Role and RoleBinding of a role template in the namespace of a single Organization or Project.
+type Target struct {
+	corev1alpha1.RoleTemplateTarget
+	// Namespace of the target, the Role and RoleBinding are created in.
+	Namespace string
+	// Organization the target belongs to.
+	Organization string
+	Role         *rbacv1.Role
+	// RoleBinding is nil, if the role template is not bound to anyone.
+	RoleBinding *rbacv1.RoleBinding
+	// Err is set, if the Role and RoleBinding couldn't be rendered for the target.
+	Err error
+}
+
+// OrganizationRoleTemplateTargets returns the targets of the OrganizationRoleTemplate in the selected ready Organizations
+// and, depending on its scopes, in their selected ready Projects. The selected Organizations are returned as well.
+func OrganizationRoleTemplateTargets(ctx context.Context, c client.Reader, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate) ([]Target, []storagev1alpha1.Organization, error) {
+	referencedRules, err := ReferencedRules(ctx, c, organizationRoleTemplate.Spec.ClusterRoleRef, organizationRoleTemplate.Spec.CRDRules)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving rules: %w", err)
+	}
+	included, includedReferencedRules, err := includedRules(ctx, c, OrganizationRoleTemplateKey(organizationRoleTemplate), organizationRoleTemplate.Spec.Includes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving includes: %w", err)
+	}
+	// Templates may predate the validation of forbidden verbs.
+	rules := append(withoutForbiddenVerbs(organizationRoleTemplate.Spec.Rules), included...)
+	referencedRules = append(referencedRules, includedReferencedRules...)
+	organizations, err := listSelectedReadyOrganizations(ctx, c, organizationRoleTemplate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing selected ready Organizations: %w", err)
+	}
+	bound := organizationRoleTemplate.HasBinding(corev1alpha1.BindToOwners) ||
+		organizationRoleTemplate.HasBinding(corev1alpha1.BindToEveryone)
+
+	var targets []Target
+	// Collect Role/RoleBindings for Organization namespaces.
+	if organizationRoleTemplate.HasScope(corev1alpha1.RoleTemplateScopeOrganization) {
+		for _, organization := range organizations {
+			target := Target{
+				RoleTemplateTarget: corev1alpha1.RoleTemplateTarget{
+					Kind:               organization.Kind,
+					APIGroup:           organization.GroupVersionKind().Group,
+					Name:               organization.Name,
+					ObservedGeneration: organization.Status.ObservedGeneration,
+				},
+				Namespace:    organization.Status.Namespace.Name,
+				Organization: organization.Name,
+			}
+			target.Role, target.RoleBinding, target.Err = rbacForTarget(organizationRoleTemplate.Spec.RoleName, organizationRoleTemplate.DefaultRoleName(), rules, referencedRules, PlaceholderData{
+				Organization: PlaceholderObject{Name: organization.Alias(), Namespace: organization.Status.Namespace.Name},
+				Namespace:    target.Namespace,
+			}, bound, organizationSubjects(organizationRoleTemplate, &organization))
+			targets = append(targets, target)
+		}
+	}
+
+	// Collect Role/RoleBindings for Project namespaces.
+	if organizationRoleTemplate.HasScope(corev1alpha1.RoleTemplateScopeProject) {
+		for _, organization := range organizations {
+			projects, err := listSelectedReadyOrganizationProjects(ctx, c, organizationRoleTemplate, &organization)
+			if err != nil {
+				return nil, nil, fmt.Errorf("listing selected ready Projects: %w", err)
+			}
+
+			for _, project := range projects {
+				target := Target{
+					RoleTemplateTarget: corev1alpha1.RoleTemplateTarget{
+						Kind:               project.Kind,
+						APIGroup:           project.GroupVersionKind().Group,
+						Name:               project.Name,
+						ObservedGeneration: project.Status.ObservedGeneration,
+					},
+					Namespace:    project.Status.Namespace.Name,
+					Organization: organization.Name,
+				}
+				target.Role, target.RoleBinding, target.Err = rbacForTarget(organizationRoleTemplate.Spec.RoleName, organizationRoleTemplate.DefaultRoleName(), rules, referencedRules, PlaceholderData{
+					Organization: PlaceholderObject{Name: organization.Alias(), Namespace: organization.Status.Namespace.Name},
+					Project:      PlaceholderObject{Name: project.Name, Namespace: project.Status.Namespace.Name},
+					Namespace:    target.Namespace,
+				}, bound, projectSubjects(organizationRoleTemplate, &organization, &project))
+				targets = append(targets, target)
+			}
+		}
+	}
+	return targets, organizations, nil
+}
+
+// ProjectRoleTemplateTargets returns the targets of the ProjectRoleTemplate in the selected ready Projects of its Organization.
+func ProjectRoleTemplateTargets(ctx context.Context, c client.Reader, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) ([]Target, error) {
+	referencedRules, err := ReferencedRules(ctx, c, projectRoleTemplate.Spec.ClusterRoleRef, projectRoleTemplate.Spec.CRDRules)
+	if err != nil {
+		return nil, fmt.Errorf("resolving rules: %w", err)
+	}
+	included, includedReferencedRules, err := includedRules(ctx, c, ProjectRoleTemplateKey(projectRoleTemplate), projectRoleTemplate.Spec.Includes)
+	if err != nil {
+		return nil, fmt.Errorf("resolving includes: %w", err)
+	}
+	// Templates may predate the validation of forbidden verbs.
+	rules := append(withoutForbiddenVerbs(projectRoleTemplate.Spec.Rules), included...)
+	referencedRules = append(referencedRules, includedReferencedRules...)
+	organization, err := organizationPlaceholder(ctx, c, projectRoleTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("getting Organization: %w", err)
+	}
+	projects, err := listSelectedReadyProjects(ctx, c, projectRoleTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("listing selected ready Projects: %w", err)
+	}
+
+	var targets []Target
+	for _, project := range projects {
+		target := Target{
+			RoleTemplateTarget: corev1alpha1.RoleTemplateTarget{
+				Kind:               project.Kind,
+				APIGroup:           project.GroupVersionKind().Group,
+				Name:               project.Name,
+				ObservedGeneration: project.Status.ObservedGeneration,
+			},
+			Namespace:    project.Status.Namespace.Name,
+			Organization: organization.Name,
+		}
+
+		var subjects []rbacv1.Subject
+		if projectRoleTemplate.HasBinding(corev1alpha1.BindToEveryone) {
+			// This is needed, because it can be the case that Organization Owner has not created any RoleBindings for Project
+			// Owner, so Project owner will not present in the project.Status.Member.
+			subjects = append(subjects, project.Spec.Owners...)
+			subjects = append(subjects, project.Status.Members...)
+		} else if projectRoleTemplate.HasBinding(corev1alpha1.BindToOwners) {
+			subjects = append(subjects, project.Spec.Owners...)
+		}
+		// ProjectRoleTemplates always have a RoleBinding, it's just empty when not bound to anyone.
+		target.Role, target.RoleBinding, target.Err = rbacForTarget(projectRoleTemplate.Spec.RoleName, projectRoleTemplate.DefaultRoleName(), rules, referencedRules, PlaceholderData{
+			Organization: organization,
+			Project:      PlaceholderObject{Name: project.Name, Namespace: project.Status.Namespace.Name},
+			Namespace:    target.Namespace,
+		}, true, subjects)
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// ProjectRoleTemplateNamespaces returns the Namespaces of the selected ready Projects, that the ProjectRoleTemplate is rolled out to.
+func ProjectRoleTemplateNamespaces(ctx context.Context, c client.Reader, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) ([]string, error) {
+	projects, err := listSelectedReadyProjects(ctx, c, projectRoleTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("listing selected ready Projects: %w", err)
+	}
+	namespaces := make([]string, 0, len(projects))
+	for _, project := range projects {
+		namespaces = append(namespaces, project.Status.Namespace.Name)
+	}
+	return namespaces, nil
+}
+
+// ReferencedRules returns the rules of the ClusterRole referenced by a role template
+// and the rules generated for matching CustomResourceDefinitions.
+// Kubernetes resolves the rules of aggregated ClusterRoles itself, so they are part of ClusterRole.Rules already.
+// Forbidden verbs are removed from the rules of the ClusterRole, like impersonate from the built-in edit and admin ClusterRoles.
+// Rules granting all verbs via the wildcard are dropped, just like the validation rejects them in the rules of role templates.
+func ReferencedRules(ctx context.Context, c client.Reader, clusterRoleRef *corev1alpha1.ObjectReference, crdRules []corev1alpha1.RoleTemplateCRDRules) ([]rbacv1.PolicyRule, error) {
+	var resolved []rbacv1.PolicyRule
+	if clusterRoleRef != nil {
+		clusterRole := &rbacv1.ClusterRole{}
+		if err := c.Get(ctx, types.NamespacedName{Name: clusterRoleRef.Name}, clusterRole); err != nil {
+			return nil, fmt.Errorf("getting ClusterRole %s: %w", clusterRoleRef.Name, err)
+		}
+		resolved = append(resolved, withoutForbiddenVerbs(clusterRole.Rules)...)
+	}
+	for _, crdRule := range crdRules {
+		generated, err := rulesForCRDs(ctx, c, crdRule)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, generated...)
+	}
+	return resolved, nil
+}
+
+// forbiddenVerbs can't be granted by role templates,
+// because they allow to bypass the privilege escalation prevention of RBAC.
+// The wildcard includes them.
+var forbiddenVerbs = map[string]bool{
+	"escalate":     true,
+	"impersonate":  true,
+	rbacv1.VerbAll: true,
+}
+
+// IsForbiddenVerb returns whether role templates must not grant the verb.
+func IsForbiddenVerb(verb string) bool {
+	return forbiddenVerbs[verb]
+}
+
+// withoutForbiddenVerbs returns the rules without the forbidden verbs. Rules that only grant forbidden verbs are dropped.
+func withoutForbiddenVerbs(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	filtered := make([]rbacv1.PolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		verbs := make([]string, 0, len(rule.Verbs))
+		for _, verb := range rule.Verbs {
+			if !forbiddenVerbs[verb] {
+				verbs = append(verbs, verb)
+			}
+		}
+		if len(verbs) == 0 {
+			continue
+		}
+		rule = *rule.DeepCopy()
+		rule.Verbs = verbs
+		filtered = append(filtered, rule)
+	}
+	return filtered
+}
+
+// rulesForCRDs returns one rule per API group, granting the verbs of the access tier on all selected CustomResourceDefinitions.
+func rulesForCRDs(ctx context.Context, c client.Reader, crdRule corev1alpha1.RoleTemplateCRDRules) ([]rbacv1.PolicyRule, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&crdRule.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CustomResourceDefinition selector: %w", err)
+	}
+	crds := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := c.List(ctx, crds, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("listing CustomResourceDefinitions: %w", err)
+	}
+	resourcesByGroup := map[string][]string{}
+	for _, crd := range crds.Items {
+		if crd.Spec.Scope != apiextensionsv1.NamespaceScoped {
+			// Roles can't grant access to cluster-scoped resources.
+			continue
+		}
+		resourcesByGroup[crd.Spec.Group] = append(resourcesByGroup[crd.Spec.Group], crd.Spec.Names.Plural)
+	}
+	groups := make([]string, 0, len(resourcesByGroup))
+	for group := range resourcesByGroup {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	rules := make([]rbacv1.PolicyRule, 0, len(groups))
+	for _, group := range groups {
+		resources := resourcesByGroup[group]
+		sort.Strings(resources)
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: resources,
+			Verbs:     crdRule.Access.Verbs(),
+		})
+	}
+	return rules, nil
+}
+
+// rbacForTarget returns the desired Role and RoleBinding of a role template rendered for a single target.
+// Referenced rules are appended to the rendered rules as they are. The RoleBinding is nil, if the role template is not bound.
+// The default role name is used, if the role template doesn't set a role name.
+func rbacForTarget(roleName, defaultRoleName string, rules, referencedRules []rbacv1.PolicyRule, data PlaceholderData, bound bool, subjects []rbacv1.Subject) (*rbacv1.Role, *rbacv1.RoleBinding, error) {
+	if roleName == "" {
+		roleName = defaultRoleName
+	}
+	name, err := RenderPlaceholders(roleName, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rendering role name: %w", err)
+	}
+	rendered, err := RenderRules(rules, data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("rendering rules: %w", err)
+	}
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: data.Namespace,
+		},
+		Rules: append(rendered, referencedRules...),
+	}
+	if !bound {
+		return role, nil, nil
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: data.Namespace,
+		},
+		Subjects: uniqueSubjects(subjects),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+	}
+	return role, roleBinding, nil
+}
+
+// organizationSubjects returns the subjects the OrganizationRoleTemplate is bound to in the Organization namespace.
+func organizationSubjects(organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate, organization *storagev1alpha1.Organization) []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	if organizationRoleTemplate.HasBinding(corev1alpha1.BindToOwners) || organizationRoleTemplate.HasBinding(corev1alpha1.BindToEveryone) {
+		subjects = append(subjects, organization.Spec.Owners...)
+	}
+	if organizationRoleTemplate.HasBinding(corev1alpha1.BindToEveryone) {
+		subjects = append(subjects, organization.Status.Members...)
+	}
+	return subjects
+}
+
+// projectSubjects returns the subjects the OrganizationRoleTemplate is bound to in the Project namespace.
+// Owners are always the Organization Owners, since OrganizationRoleTemplate is used to config permissions of Organization Owners,
+// while Everyone additionally covers all owners and members of the Project.
+func projectSubjects(organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate, organization *storagev1alpha1.Organization, project *storagev1alpha1.Project) []rbacv1.Subject {
+	var subjects []rbacv1.Subject
+	if organizationRoleTemplate.HasBinding(corev1alpha1.BindToOwners) || organizationRoleTemplate.HasBinding(corev1alpha1.BindToEveryone) {
+		subjects = append(subjects, organization.Spec.Owners...)
+	}
+	if organizationRoleTemplate.HasBinding(corev1alpha1.BindToEveryone) {
+		subjects = append(subjects, project.Spec.Owners...)
+		subjects = append(subjects, project.Status.Members...)
+	}
+	return subjects
+}
+
+// uniqueSubjects sorts the subjects and removes duplicates.
+func uniqueSubjects(subjects []rbacv1.Subject) []rbacv1.Subject {
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].String() < subjects[j].String()
+	})
+	unique := make([]rbacv1.Subject, 0, len(subjects))
+	for i := range subjects {
+		if i == 0 || subjects[i-1].String() != subjects[i].String() {
+			unique = append(unique, subjects[i])
+		}
+	}
+	return unique
+}
+
+// organizationPlaceholder describes the Organization the ProjectRoleTemplate belongs to, as recorded in the labels of its Namespace.
+func organizationPlaceholder(ctx context.Context, c client.Reader, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) (PlaceholderObject, error) {
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: projectRoleTemplate.Namespace}, namespace); err != nil {
+		return PlaceholderObject{}, fmt.Errorf("getting Namespace: %w", err)
+	}
+	name := namespace.Labels[storagev1alpha1.OrganizationNameLabel]
+	if name == "" {
+		name = namespace.Labels[owner.OwnerNameLabel]
+	}
+	return PlaceholderObject{
+		Name:      name,
+		Namespace: namespace.Name,
+	}, nil
+}
+
+// listSelectedReadyOrganizations returns the ready Organizations that are selected by the OrganizationRoleTemplate
+// and did not opt out of it.
+func listSelectedReadyOrganizations(ctx context.Context, c client.Reader, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate) ([]storagev1alpha1.Organization, error) {
+	organizationSelector := labels.Everything()
+	if organizationRoleTemplate.Spec.OrganizationSelector != nil {
+		var err error
+		organizationSelector, err = metav1.LabelSelectorAsSelector(organizationRoleTemplate.Spec.OrganizationSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Organization selector: %w", err)
+		}
+	}
+	organizations := &storagev1alpha1.OrganizationList{}
+	if err := c.List(ctx, organizations, client.MatchingLabelsSelector{Selector: organizationSelector}); err != nil {
+		return nil, fmt.Errorf("listing Organizations: %w", err)
+	}
+	var readyOrganizations []storagev1alpha1.Organization
+	for _, organization := range organizations.Items {
+		if !organization.IsReady() ||
+			organization.ExcludesOrganizationRoleTemplate(organizationRoleTemplate.Name) {
+			continue
+		}
+		readyOrganizations = append(readyOrganizations, organization)
+	}
+	return readyOrganizations, nil
+}
+
+// listSelectedReadyOrganizationProjects returns the ready Projects of the Organization that are selected by the OrganizationRoleTemplate.
+func listSelectedReadyOrganizationProjects(ctx context.Context, c client.Reader, organizationRoleTemplate *corev1alpha1.OrganizationRoleTemplate, organization *storagev1alpha1.Organization) ([]storagev1alpha1.Project, error) {
+	projectSelector := labels.Everything()
+	if organizationRoleTemplate.Spec.ProjectSelector != nil {
+		var err error
+		projectSelector, err = metav1.LabelSelectorAsSelector(organizationRoleTemplate.Spec.ProjectSelector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Project selector: %w", err)
+		}
+	}
+	projects := &storagev1alpha1.ProjectList{}
+	if err := c.List(ctx, projects, client.InNamespace(organization.Status.Namespace.Name), client.MatchingLabelsSelector{Selector: projectSelector}); err != nil {
+		return nil, fmt.Errorf("listing Projects: %w", err)
+	}
+	var readyProjects []storagev1alpha1.Project
+	for _, project := range projects.Items {
+		if project.IsReady() {
+			readyProjects = append(readyProjects, project)
+		}
+	}
+	return readyProjects, nil
+}
+
+// listSelectedReadyProjects returns the ready Projects in the namespace of the ProjectRoleTemplate that are selected by it.
+func listSelectedReadyProjects(ctx context.Context, c client.Reader, projectRoleTemplate *corev1alpha1.ProjectRoleTemplate) ([]storagev1alpha1.Project, error) {
+	projectSelector, err := metav1.LabelSelectorAsSelector(projectRoleTemplate.Spec.ProjectSelector)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Project selector: %w", err)
+	}
+	projects := &storagev1alpha1.ProjectList{}
+	if err := c.List(ctx, projects, client.InNamespace(projectRoleTemplate.Namespace), client.MatchingLabelsSelector{Selector: projectSelector}); err != nil {
+		return nil, fmt.Errorf("listing Project: %w", err)
+	}
+	var readyProjects []storagev1alpha1.Project
+	for _, project := range projects.Items {
+		if project.IsReady() {
+			readyProjects = append(readyProjects, project)
+		}
+	}
+	return readyProjects, nil
+}
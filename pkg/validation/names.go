@@ -0,0 +1,56 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ReservedNames holds names and name prefixes that can't be used for Organizations and Projects,
+// because their Namespaces would clash with Namespaces of the cluster or of Bulward itself.
+type ReservedNames struct {
+	// Names that are reserved.
+	Names []string
+	// Prefixes that no name may start with.
+	Prefixes []string
+}
+
+// DefaultReservedNames returns the names and prefixes of the Namespaces managed by Kubernetes and Bulward.
+func DefaultReservedNames() ReservedNames {
+	return ReservedNames{
+		Names:    []string{"default", "kube-system", "kube-public", "kube-node-lease"},
+		Prefixes: []string{"kube-", "bulward-"},
+	}
+}
+
+// ValidateName checks that the given name is neither reserved nor starts with a reserved prefix.
+func (r ReservedNames) ValidateName(name string, fldPath *field.Path) field.ErrorList {
+	for _, reserved := range r.Names {
+		if name == reserved {
+			return field.ErrorList{field.Invalid(fldPath, name, "name is reserved")}
+		}
+	}
+	for _, prefix := range r.Prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return field.ErrorList{field.Invalid(fldPath, name, fmt.Sprintf("names starting with %q are reserved", prefix))}
+		}
+	}
+	return nil
+}
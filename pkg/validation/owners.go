@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ValidateOwners makes sure that the given owners list does not orphan an Organization or Project.
+// The list is rejected if it is empty, or if every owner is a ServiceAccount that does not exist anymore.
+// Users and Groups can't be looked up, so a single User or Group owner is enough to pass the check.
+func ValidateOwners(ctx context.Context, c client.Reader, owners []rbacv1.Subject, fldPath *field.Path) (field.ErrorList, error) {
+	if len(owners) == 0 {
+		return field.ErrorList{field.Invalid(fldPath, owners, "at least one owner is required")}, nil
+	}
+	for _, owner := range owners {
+		if owner.Kind != rbacv1.ServiceAccountKind {
+			return nil, nil
+		}
+		serviceAccount := &corev1.ServiceAccount{}
+		err := c.Get(ctx, types.NamespacedName{
+			Name:      owner.Name,
+			Namespace: owner.Namespace,
+		}, serviceAccount)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("getting ServiceAccount %s/%s: %w", owner.Namespace, owner.Name, err)
+		}
+	}
+	return field.ErrorList{field.Invalid(fldPath, owners, "all owners are deleted ServiceAccounts")}, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"k8c.io/utils/pkg/owner"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/templates"
+)
+
+// ValidateRoleTemplatePlaceholders checks that the placeholders of the role name and of the resource names of the rules can be rendered.
+func ValidateRoleTemplatePlaceholders(roleName string, rules []rbacv1.PolicyRule, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if err := templates.ValidatePlaceholders(roleName); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("roleName"), roleName, err.Error()))
+	}
+	for i, rule := range rules {
+		for j, resourceName := range rule.ResourceNames {
+			if err := templates.ValidatePlaceholders(resourceName); err != nil {
+				errs = append(errs, field.Invalid(fldPath.Child("rules").Index(i).Child("resourceNames").Index(j), resourceName, err.Error()))
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateOrganizationRoleTemplateSpec checks the structure of the OrganizationRoleTemplate spec.
+func ValidateOrganizationRoleTemplateSpec(spec *corev1alpha1.OrganizationRoleTemplateSpec, fldPath *field.Path) field.ErrorList {
+	errs := validateRoleTemplateRules(spec.Rules, spec.ClusterRoleRef, spec.CRDRules, spec.Includes, fldPath)
+	errs = append(errs, ValidateRoleTemplatePlaceholders(spec.RoleName, spec.Rules, fldPath)...)
+	errs = append(errs, metav1validation.ValidateLabelSelector(spec.OrganizationSelector, fldPath.Child("organizationSelector"))...)
+	errs = append(errs, metav1validation.ValidateLabelSelector(spec.ProjectSelector, fldPath.Child("projectSelector"))...)
+	if spec.RolloutStrategy != nil {
+		errs = append(errs, metav1validation.ValidateLabelSelector(spec.RolloutStrategy.Canary, fldPath.Child("rolloutStrategy", "canary"))...)
+	}
+	return errs
+}
+
+// ValidateProjectRoleTemplateSpec checks the structure of the ProjectRoleTemplate spec.
+func ValidateProjectRoleTemplateSpec(spec *corev1alpha1.ProjectRoleTemplateSpec, fldPath *field.Path) field.ErrorList {
+	errs := validateRoleTemplateRules(spec.Rules, spec.ClusterRoleRef, spec.CRDRules, spec.Includes, fldPath)
+	errs = append(errs, ValidateRoleTemplatePlaceholders(spec.RoleName, spec.Rules, fldPath)...)
+	errs = append(errs, metav1validation.ValidateLabelSelector(spec.ProjectSelector, fldPath.Child("projectSelector"))...)
+	return errs
+}
+
+// ValidateProjectRoleTemplateNamespace makes sure that the ProjectRoleTemplate is created in the Namespace of an Organization,
+// because ProjectRoleTemplates select the Projects of the Organization owning their Namespace.
+func ValidateProjectRoleTemplateNamespace(ctx context.Context, c client.Reader, namespace string, fldPath *field.Path) (field.ErrorList, error) {
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(fldPath, namespace)}, nil
+		}
+		return nil, fmt.Errorf("getting Namespace %s: %w", namespace, err)
+	}
+	if ns.Labels[owner.OwnerTypeLabel] != storagev1alpha1.SchemeGroupVersion.WithKind("Organization").GroupKind().String() {
+		return field.ErrorList{field.Invalid(fldPath, namespace, "ProjectRoleTemplates can only be created in the Namespace of an Organization")}, nil
+	}
+	return nil, nil
+}
+
+// validateRoleTemplateRules checks that the role template grants anything and that its rules are valid namespaced rules.
+func validateRoleTemplateRules(
+	rules []rbacv1.PolicyRule, clusterRoleRef *corev1alpha1.ObjectReference,
+	crdRules []corev1alpha1.RoleTemplateCRDRules, includes []corev1alpha1.RoleTemplateReference, fldPath *field.Path,
+) field.ErrorList {
+	var errs field.ErrorList
+	rulesPath := fldPath.Child("rules")
+	if len(rules) == 0 && clusterRoleRef == nil && len(crdRules) == 0 && len(includes) == 0 {
+		errs = append(errs, field.Required(rulesPath, "rules are required, unless clusterRoleRef, crdRules or includes are set"))
+	}
+	for i, rule := range rules {
+		rulePath := rulesPath.Index(i)
+		if len(rule.Verbs) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("verbs"), "verbs must contain at least one value"))
+		}
+		for j, verb := range rule.Verbs {
+			if templates.IsForbiddenVerb(verb) {
+				errs = append(errs, field.Forbidden(rulePath.Child("verbs").Index(j), fmt.Sprintf("role templates must not grant %q", verb)))
+			}
+		}
+		if len(rule.NonResourceURLs) > 0 {
+			errs = append(errs, field.Invalid(rulePath.Child("nonResourceURLs"), rule.NonResourceURLs, "namespaced rules cannot apply to non-resource URLs"))
+			continue
+		}
+		if len(rule.Resources) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("resources"), "resource rules must supply at least one resource"))
+		}
+		if len(rule.APIGroups) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("apiGroups"), "resource rules must supply at least one api group"))
+		}
+	}
+	for i, crdRule := range crdRules {
+		errs = append(errs, metav1validation.ValidateLabelSelector(&crdRule.Selector, fldPath.Child("crdRules").Index(i).Child("selector"))...)
+	}
+	return errs
+}
@@ -18,6 +18,7 @@ package test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	"k8c.io/bulward/pkg/apis/apiserver"
 	apiserverv1alpha1 "k8c.io/bulward/pkg/apis/apiserver/v1alpha1"
 	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
@@ -289,3 +291,201 @@ func TestVisibleFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestOrganizationNameAlias(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	var orgs []*apiserverv1alpha1.Organization
+	var clients []*testutil.RecordingClient
+	for _, user := range []string{"alias-user-a", "alias-user-b"} {
+		cfg, err := ctrl.GetConfig()
+		require.NoError(t, err)
+		cfg.Impersonate = rest.ImpersonationConfig{UserName: user}
+		userClient := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+		t.Cleanup(userClient.CleanUpFunc(ctx))
+
+		org := &apiserverv1alpha1.Organization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-alias",
+			},
+			Spec: storagev1alpha1.OrganizationSpec{
+				Metadata: &storagev1alpha1.OrganizationMetadata{
+					DisplayName: "test",
+					Description: "desc",
+				},
+				Owners: []rbacv1.Subject{{
+					Kind:     rbacv1.UserKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     user,
+				}},
+			},
+		}
+		require.NoError(t, userClient.Create(ctx, org), "the same name should be usable by different users")
+		require.NoError(t, testutil.WaitUntilReady(ctx, userClient, org))
+		orgs = append(orgs, org)
+		clients = append(clients, userClient)
+	}
+
+	t.Log("each user gets their own Organization")
+	for i, userClient := range clients {
+		org := &apiserverv1alpha1.Organization{}
+		require.NoError(t, userClient.Get(ctx, types.NamespacedName{Name: "test-alias"}, org))
+		assert.Equal(t, orgs[i].UID, org.UID)
+		assert.Equal(t, "test-alias", org.Name)
+		assert.NotContains(t, org.Labels, storagev1alpha1.OrganizationNameLabel)
+	}
+	assert.NotEqual(t, orgs[0].Status.Namespace.Name, orgs[1].Status.Namespace.Name)
+
+	t.Log("storage Organizations use generated names")
+	storageOrgs := &storagev1alpha1.OrganizationList{}
+	require.NoError(t, cl.List(ctx, storageOrgs, client.MatchingLabels{
+		storagev1alpha1.OrganizationNameLabel: "test-alias",
+	}))
+	if assert.Len(t, storageOrgs.Items, 2) {
+		for _, storageOrg := range storageOrgs.Items {
+			assert.True(t, strings.HasPrefix(storageOrg.Name, "test-alias-"), "storage name should be generated, got %s", storageOrg.Name)
+		}
+	}
+
+	t.Log("names are unique for a single user")
+	err = clients[0].Create(ctx, &apiserverv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-alias",
+		},
+		Spec: orgs[0].Spec,
+	})
+	assert.True(t, errors.IsAlreadyExists(err), "creating a visible name twice should fail with AlreadyExists, got %v", err)
+}
+
+func TestOrganizationReservedNames(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	for _, name := range []string{"default", "kube-system", "kube-test", "bulward-test"} {
+		org := &apiserverv1alpha1.Organization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: storagev1alpha1.OrganizationSpec{
+				Metadata: &storagev1alpha1.OrganizationMetadata{
+					DisplayName: "test",
+					Description: "desc",
+				},
+				Owners: []rbacv1.Subject{{
+					Kind:     rbacv1.UserKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     "kubernetes-admin",
+				}},
+			},
+		}
+		err := cl.Create(ctx, org)
+		assert.True(t, errors.IsInvalid(err), "creating Organization %s should be invalid, got %v", name, err)
+	}
+}
+
+func TestOrganizationDeleteCollection(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	user := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "delete-collection-user",
+	}
+	admin := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "kubernetes-admin",
+	}
+	userCfg, err := config.GetConfig()
+	require.NoError(t, err)
+	userCfg.Impersonate = rest.ImpersonationConfig{UserName: user.Name}
+	userClient := testutil.NewRecordingClient(t, userCfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(userClient.CleanUpFunc(ctx))
+
+	newOrg := func(name string, owner rbacv1.Subject) *apiserverv1alpha1.Organization {
+		return &apiserverv1alpha1.Organization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"test-name": t.Name(),
+				},
+			},
+			Spec: storagev1alpha1.OrganizationSpec{
+				Metadata: &storagev1alpha1.OrganizationMetadata{
+					DisplayName: "test",
+					Description: "desc",
+				},
+				Owners: []rbacv1.Subject{owner},
+			},
+		}
+	}
+	invisibleOrg := newOrg("test-delete-collection-invisible", admin)
+	require.NoError(t, cl.Create(ctx, invisibleOrg))
+	visibleOrg := newOrg("test-delete-collection-visible", admin)
+	require.NoError(t, cl.Create(ctx, visibleOrg))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, visibleOrg))
+	require.NoError(t, cl.Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "member",
+			Namespace: visibleOrg.Status.Namespace.Name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     "test",
+		},
+		Subjects: []rbacv1.Subject{user},
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, visibleOrg, func() (done bool, err error) {
+		for _, member := range visibleOrg.Status.Members {
+			if member == user {
+				return true, nil
+			}
+		}
+		return false, nil
+	}))
+	ownedOrg := newOrg("test-delete-collection-owned", user)
+	require.NoError(t, userClient.Create(ctx, ownedOrg))
+
+	t.Log("only owned Organizations are deleted, visible ones are reported")
+	err = userClient.DeleteAllOf(ctx, &apiserverv1alpha1.Organization{}, client.MatchingLabels{"test-name": t.Name()})
+	if assert.True(t, errors.IsForbidden(err), "deleting visible, not owned organizations should be forbidden, got %v", err) {
+		assert.Contains(t, err.Error(), visibleOrg.Name)
+		assert.NotContains(t, err.Error(), invisibleOrg.Name)
+		status := err.(errors.APIStatus).Status()
+		if assert.NotNil(t, status.Details) {
+			assert.Contains(t, status.Details.Causes, metav1.StatusCause{
+				Type:    apiserver.DeletedCauseType,
+				Message: "deleted",
+				Field:   ownedOrg.Name,
+			})
+			var failed []string
+			for _, cause := range status.Details.Causes {
+				if cause.Type != apiserver.DeletedCauseType {
+					failed = append(failed, cause.Field)
+				}
+			}
+			assert.Equal(t, []string{visibleOrg.Name}, failed)
+		}
+	}
+	require.NoError(t, testutil.WaitUntilNotFound(ctx, userClient, ownedOrg))
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: visibleOrg.Name}, visibleOrg))
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: invisibleOrg.Name}, invisibleOrg))
+}
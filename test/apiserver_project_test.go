@@ -24,11 +24,13 @@ import (
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 	corev1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -60,15 +62,39 @@ func TestAPIServerProject(t *testing.T) {
 	require.NoError(t, err)
 	dcl, err := dynamic.NewForConfig(cfg)
 	require.NoError(t, err)
-	ns := &v1.Namespace{}
-	ns.Name = "test-org"
-	require.NoError(t, cl.Create(ctx, ns))
-
 	owner := rbacv1.Subject{
 		Kind:     rbacv1.UserKind,
 		APIGroup: rbacv1.GroupName,
 		Name:     "kubernetes-admin",
 	}
+	org := &apiserverv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-org",
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "test",
+				Description: "test",
+			},
+			Owners: []rbacv1.Subject{owner},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+	ns := &v1.Namespace{}
+	ns.Name = org.Status.Namespace.Name
+
+	t.Log("projects can only be created in Organization namespaces")
+	err = cl.Create(ctx, &apiserverv1alpha1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: storagev1alpha1.ProjectSpec{
+			Owners: []rbacv1.Subject{owner},
+		},
+	})
+	assert.True(t, errors.IsNotFound(err), "creating a project outside of an organization should fail with NotFound, got %v", err)
 	project := &apiserverv1alpha1.Project{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
@@ -80,7 +106,7 @@ func TestAPIServerProject(t *testing.T) {
 	}
 
 	wi, err := dcl.Resource(projectGvr).Watch(ctx, metav1.ListOptions{
-		FieldSelector: "metadata.name=test,metadata.namespace=test-org",
+		FieldSelector: "metadata.name=test,metadata.namespace=" + ns.Name,
 	})
 	require.NoError(t, err)
 	eventTracer := events.NewTracer(wi, events.IsObjectName("test"))
@@ -125,3 +151,82 @@ func TestAPIServerProject(t *testing.T) {
 	assert.NoError(t, cl.Delete(ctx, project))
 	require.NoError(t, eventTracer.WaitUntil(ctx, events.IsType(watch.Deleted)))
 }
+
+func TestAPIServerProjectCreationAccess(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	member := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "project-creation-member",
+	}
+	org := &apiserverv1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-project-creation",
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "test",
+				Description: "test",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "kubernetes-admin",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+	require.NoError(t, cl.Create(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "member",
+			Namespace: org.Status.Namespace.Name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     "does-not-matter",
+		},
+		Subjects: []rbacv1.Subject{member},
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, org, func() (done bool, err error) {
+		for _, m := range org.Status.Members {
+			if m == member {
+				return true, nil
+			}
+		}
+		return false, nil
+	}))
+
+	createProject := func(userName string) error {
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		cfg.Impersonate = rest.ImpersonationConfig{UserName: userName}
+		userClient := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+		t.Cleanup(userClient.CleanUpFunc(ctx))
+		return userClient.Create(ctx, &apiserverv1alpha1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: org.Status.Namespace.Name,
+			},
+			Spec: storagev1alpha1.ProjectSpec{
+				Owners: []rbacv1.Subject{{
+					Kind:     rbacv1.UserKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     userName,
+				}},
+			},
+		})
+	}
+
+	err = createProject("project-creation-stranger")
+	assert.True(t, errors.IsNotFound(err) || errors.IsForbidden(err), "non-members must not create projects, got %v", err)
+	err = createProject(member.Name)
+	assert.True(t, errors.IsForbidden(err), "members without project-admin rights must not create projects, got %v", err)
+}
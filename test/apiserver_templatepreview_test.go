@@ -0,0 +1,207 @@
+/*
+Copyright 2020 The Bulward Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/kubermatic/utils/pkg/testutil"
+
+	apiserverv1alpha1 "k8c.io/bulward/pkg/apis/apiserver/v1alpha1"
+	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
+	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+)
+
+func TestAPIServerTemplatePreview(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+	// TemplatePreviews are not stored, so they can't be cleaned up by the recording client.
+	previewClient, err := client.New(cfg, client.Options{Scheme: testScheme})
+	require.NoError(t, err)
+
+	selector := map[string]string{"bulward.io/test": strings.ToLower(t.Name())}
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   strings.ToLower(t.Name()),
+			Labels: selector,
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "preview",
+				Description: "an organization for previews",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1alpha1.GroupVersion.String(),
+			Kind:       "OrganizationRoleTemplate",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes:               []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo:               []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			OrganizationSelector: &metav1.LabelSelector{MatchLabels: selector},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"apiserver.bulward.io"},
+				Resources: []string{"projects"},
+				Verbs:     []string{"get"},
+			}},
+		},
+	}
+	preview := func(template runtime.Object) *apiserverv1alpha1.TemplatePreview {
+		raw, err := json.Marshal(template)
+		require.NoError(t, err)
+		preview := &apiserverv1alpha1.TemplatePreview{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: strings.ToLower(t.Name()),
+			},
+			Spec: corev1alpha1.TemplatePreviewSpec{
+				Template: runtime.RawExtension{Raw: raw},
+			},
+		}
+		require.NoError(t, previewClient.Create(ctx, preview))
+		return preview
+	}
+
+	t.Log("previewing a new template only creates objects")
+	created := preview(template)
+	if assert.Len(t, created.Status.Targets, 1) {
+		assert.Equal(t, org.Name, created.Status.Targets[0].Name)
+		assert.Equal(t, org.Status.Namespace.Name, created.Status.Targets[0].Namespace)
+	}
+	if assert.Len(t, created.Status.Changes, 2) {
+		for _, change := range created.Status.Changes {
+			assert.Equal(t, corev1alpha1.RBACChangeCreate, change.Type)
+			assert.Equal(t, template.DefaultRoleName(), change.Name)
+			assert.Nil(t, change.Current)
+		}
+	}
+	role := &rbacv1.Role{}
+	err = cl.Get(ctx, client.ObjectKey{Name: template.DefaultRoleName(), Namespace: org.Status.Namespace.Name}, role)
+	assert.True(t, errors.IsNotFound(err), "previews must not create Roles, got %v", err)
+
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+	role.Name = template.DefaultRoleName()
+	role.Namespace = org.Status.Namespace.Name
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, role))
+
+	t.Log("previewing a changed template updates the Role")
+	changed := template.DeepCopy()
+	changed.Spec.Rules[0].Verbs = []string{"get", "list"}
+	updated := preview(changed)
+	if assert.Len(t, updated.Status.Changes, 1) {
+		change := updated.Status.Changes[0]
+		assert.Equal(t, corev1alpha1.RBACChangeUpdate, change.Type)
+		assert.Equal(t, "Role", change.Kind)
+		if assert.NotNil(t, change.Current) && assert.NotNil(t, change.Desired) {
+			assert.Equal(t, []string{"get"}, change.Current.Rules[0].Verbs)
+			assert.Equal(t, []string{"get", "list"}, change.Desired.Rules[0].Verbs)
+		}
+	}
+
+	t.Log("previewing a template that no longer selects the Organization deletes its objects")
+	deselected := template.DeepCopy()
+	deselected.Spec.OrganizationSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"bulward.io/test": "nothing"}}
+	deleted := preview(deselected)
+	assert.Empty(t, deleted.Status.Targets)
+	if assert.Len(t, deleted.Status.Changes, 2) {
+		for _, change := range deleted.Status.Changes {
+			assert.Equal(t, corev1alpha1.RBACChangeDelete, change.Type)
+			assert.Nil(t, change.Desired)
+		}
+	}
+	require.NoError(t, cl.Get(ctx, client.ObjectKey{Name: role.Name, Namespace: role.Namespace}, role), "previews must not delete Roles")
+
+	t.Log("previews require access to the Roles and RoleBindings they show")
+	author := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Template Author",
+	}
+	authorRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()) + "-author",
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{corev1alpha1.GroupVersion.Group},
+			Resources: []string{"organizationroletemplates"},
+			Verbs:     []string{"update"},
+		}, {
+			APIGroups: []string{apiserverv1alpha1.SchemeGroupVersion.Group},
+			Resources: []string{"templatepreviews"},
+			Verbs:     []string{"create"},
+		}},
+	}
+	require.NoError(t, cl.Create(ctx, authorRole))
+	require.NoError(t, cl.Create(ctx, &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: authorRole.Name,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     authorRole.Name,
+		},
+		Subjects: []rbacv1.Subject{author},
+	}))
+	authorCfg := rest.CopyConfig(cfg)
+	authorCfg.Impersonate = rest.ImpersonationConfig{UserName: author.Name}
+	authorClient, err := client.New(authorCfg, client.Options{Scheme: testScheme})
+	require.NoError(t, err)
+	raw, err := json.Marshal(changed)
+	require.NoError(t, err)
+	err = authorClient.Create(ctx, &apiserverv1alpha1.TemplatePreview{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.TemplatePreviewSpec{
+			Template: runtime.RawExtension{Raw: raw},
+		},
+	})
+	require.Error(t, err, "the author can't read the RoleBindings of the Organization")
+	assert.True(t, errors.IsForbidden(err), "expected Forbidden, got %v", err)
+	assert.Contains(t, err.Error(), "in namespace "+org.Status.Namespace.Name)
+}
@@ -20,19 +20,29 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8c.io/utils/pkg/owner"
 	"k8c.io/utils/pkg/testutil"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
 	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/naming"
 	"k8c.io/bulward/pkg/templates"
 )
 
@@ -40,6 +50,7 @@ func init() {
 	utilruntime.Must(corev1alpha1.AddToScheme(testScheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(testScheme))
 	utilruntime.Must(storagev1alpha1.AddToScheme(testScheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(testScheme))
 }
 
 func TestStorageOrganization(t *testing.T) {
@@ -152,7 +163,7 @@ func TestStorageOrganization(t *testing.T) {
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: rbacv1.GroupName,
 			Kind:     "Role",
-			Name:     rbacTemplate.Name,
+			Name:     rbacTemplate.DefaultRoleName(),
 		},
 	}
 	require.NoError(t, ownerClient.Create(ctx, rb))
@@ -168,3 +179,1282 @@ func TestStorageOrganization(t *testing.T) {
 		return len(org.Status.Members) == 2, nil
 	}), "organization owner can not remove organization member")
 }
+
+func TestStorageOrganizationOwnersValidation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "munich",
+				Description: "an organization that must never be orphaned",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	updateOwners := func(owners []rbacv1.Subject) error {
+		return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := cl.Get(ctx, types.NamespacedName{Name: org.Name}, org); err != nil {
+				return err
+			}
+			org.Spec.Owners = owners
+			return cl.Update(ctx, org)
+		})
+	}
+
+	t.Log("removing all owners is rejected")
+	err = updateOwners(nil)
+	assert.True(t, errors.IsInvalid(err), "removing all owners should be invalid, got %v", err)
+
+	t.Log("only deleted ServiceAccounts as owners are rejected")
+	err = updateOwners([]rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "does-not-exist",
+		Namespace: "default",
+	}})
+	assert.True(t, errors.IsInvalid(err), "deleted ServiceAccount owners should be invalid, got %v", err)
+
+	t.Log("existing ServiceAccounts are valid owners")
+	require.NoError(t, updateOwners([]rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "default",
+		Namespace: "default",
+	}}))
+}
+
+func TestStorageOrganizationNamespaceConflict(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "hamburg",
+				Description: "an organization trying to take over a namespace",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	nsName, err := naming.DefaultNamespacePolicy().OrganizationNamespace(org)
+	require.NoError(t, err)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nsName,
+		},
+	}
+	require.NoError(t, cl.Create(ctx, ns))
+
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, cl.WaitUntil(ctx, org, func() (done bool, err error) {
+		condition, _ := org.Status.GetCondition(storagev1alpha1.OrganizationNamespaceConflict)
+		return condition.Status == storagev1alpha1.ConditionTrue, nil
+	}))
+	assert.False(t, org.IsReady())
+	assert.Nil(t, org.Status.Namespace)
+
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: ns.Name}, ns))
+	assert.NotContains(t, ns.Labels, owner.OwnerNameLabel, "namespace must not be adopted")
+}
+
+func TestStorageOrganizationManagedObjectProtection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	organizationOwner := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Organization Owner",
+	}
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "luebeck",
+				Description: "an organization with owners trying to change managed objects",
+			},
+			Owners: []rbacv1.Subject{organizationOwner},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	rbacTemplate := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: templates.RBACAdminOrganizationRoleTemplateName,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, rbacTemplate))
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rbacTemplate.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return role.Labels[corev1alpha1.ManagedLabel] == "true", nil
+	}), "Roles of role templates must be labelled as managed")
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rbacTemplate.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, roleBinding, func() (done bool, err error) {
+		return roleBinding.Labels[corev1alpha1.ManagedLabel] == "true", nil
+	}), "RoleBindings of role templates must be labelled as managed")
+
+	t.Log("Organization Owner can't change or delete managed Roles and RoleBindings")
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: organizationOwner.Name,
+	}
+	ownerClient := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	role.Rules = append(role.Rules, rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"configmaps"},
+		Verbs:     []string{"get"},
+	})
+	err = ownerClient.Update(ctx, role)
+	assert.True(t, errors.IsForbidden(err), "updating a managed Role must be forbidden, got %v", err)
+	if err != nil {
+		assert.Contains(t, err.Error(), rbacTemplate.Name, "the owning template must be named")
+	}
+	err = ownerClient.Delete(ctx, role)
+	assert.True(t, errors.IsForbidden(err), "deleting a managed Role must be forbidden, got %v", err)
+	err = ownerClient.Delete(ctx, roleBinding)
+	assert.True(t, errors.IsForbidden(err), "deleting a managed RoleBinding must be forbidden, got %v", err)
+
+	t.Log("Organization Owner can't create Roles posing as managed ones")
+	for _, label := range []string{corev1alpha1.ManagedLabel, owner.OwnerTypeLabel} {
+		forgedRole := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "forged",
+				Namespace: org.Status.Namespace.Name,
+				Labels:    map[string]string{label: "true"},
+			},
+		}
+		err = ownerClient.Create(ctx, forgedRole)
+		if assert.True(t, errors.IsForbidden(err), "creating a Role with label %s must be forbidden, got %v", label, err) {
+			assert.Contains(t, err.Error(), "reserved")
+		}
+	}
+
+	t.Log("managed Namespaces are only deleted by the manager")
+	ns := &corev1.Namespace{}
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: org.Status.Namespace.Name}, ns))
+	assert.Equal(t, "true", ns.Labels[corev1alpha1.ManagedLabel])
+	err = cl.Delete(ctx, ns)
+	assert.True(t, errors.IsForbidden(err), "deleting a managed Namespace must be forbidden, got %v", err)
+	if err != nil {
+		assert.Contains(t, err.Error(), org.Alias(), "the owning Organization must be named")
+	}
+
+	require.NoError(t, testutil.DeleteAndWaitUntilNotFound(ctx, cl, org))
+	require.NoError(t, cl.WaitUntilNotFound(ctx, ns), "the manager must delete the Namespace of the Organization")
+}
+
+func TestStorageOrganizationDefaultRoleTemplates(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	defaultTemplate := func(name string) *corev1alpha1.OrganizationRoleTemplate {
+		return &corev1alpha1.OrganizationRoleTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: strings.ToLower(t.Name()) + "-" + name,
+				Labels: map[string]string{
+					corev1alpha1.DefaultRoleTemplateLabel: "true",
+				},
+			},
+			Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+				Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+				BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+				Rules: []rbacv1.PolicyRule{
+					{
+						APIGroups: []string{"apiserver.bulward.io"},
+						Resources: []string{"projects"},
+						Verbs:     []string{"get"},
+					},
+				},
+			},
+		}
+	}
+	// Managed defaults, that are not part of the default set anymore, are deleted.
+	removed := defaultTemplate("removed")
+	require.NoError(t, cl.Create(ctx, removed))
+	// Unmanaged defaults are left alone.
+	unmanaged := defaultTemplate("unmanaged")
+	unmanaged.Annotations = map[string]string{corev1alpha1.RoleTemplateUnmanagedAnnotation: "true"}
+	require.NoError(t, cl.Create(ctx, unmanaged))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "leipzig",
+				Description: "an organization reconciling the default role templates",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	for _, name := range []string{templates.ProjectAdminOrganizationRoleTemplateName, templates.RBACAdminOrganizationRoleTemplateName} {
+		template := &corev1alpha1.OrganizationRoleTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+		require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+		assert.Equal(t, "true", template.Labels[corev1alpha1.DefaultRoleTemplateLabel], "default %s must be managed", name)
+	}
+	require.NoError(t, testutil.WaitUntilNotFound(ctx, cl, removed))
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: unmanaged.Name}, unmanaged))
+}
+
+func TestStorageOrganizationRoleCatalog(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	organizationOwner := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Organization Owner",
+	}
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "potsdam",
+				Description: "an organization using the role catalog",
+			},
+			Owners: []rbacv1.Subject{organizationOwner},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+	project := &storagev1alpha1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "project",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: storagev1alpha1.ProjectSpec{
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Project Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, project))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, project))
+
+	for _, catalogTemplate := range templates.CatalogOrganizationRoleTemplates() {
+		template := &corev1alpha1.OrganizationRoleTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: catalogTemplate.Name,
+			},
+		}
+		require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+		assert.Equal(t, templates.CatalogVersion, template.Annotations[corev1alpha1.RoleTemplateVersionAnnotation])
+
+		namespace := org.Status.Namespace.Name
+		if template.HasScope(corev1alpha1.RoleTemplateScopeProject) {
+			namespace = project.Status.Namespace.Name
+		}
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      template.Spec.RoleName,
+				Namespace: namespace,
+			},
+		}
+		require.NoError(t, testutil.WaitUntilFound(ctx, cl, role))
+		assert.NotEmpty(t, role.Rules, "Role %s must copy the rules of ClusterRole %s", role.Name, template.Spec.ClusterRoleRef.Name)
+		for _, rule := range role.Rules {
+			assert.NotContains(t, rule.Verbs, "impersonate", "Role %s must not grant impersonate of ClusterRole %s", role.Name, template.Spec.ClusterRoleRef.Name)
+			assert.NotContains(t, rule.Verbs, "escalate", "Role %s must not grant escalate of ClusterRole %s", role.Name, template.Spec.ClusterRoleRef.Name)
+		}
+
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      role.Name,
+				Namespace: namespace,
+			},
+		}
+		require.NoError(t, testutil.WaitUntilFound(ctx, cl, roleBinding))
+		assert.Contains(t, roleBinding.Subjects, organizationOwner)
+	}
+}
+
+func TestStorageOrganizationRoleTemplateBindToEveryone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	organizationOwner := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Organization Owner",
+	}
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "dresden",
+				Description: "an organization where everyone can look around",
+			},
+			Owners: []rbacv1.Subject{organizationOwner},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	member := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "User1",
+	}
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "user1-rb",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Subjects: []rbacv1.Subject{member},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     "role",
+		},
+	}
+	require.NoError(t, cl.Create(ctx, rb))
+	require.NoError(t, cl.WaitUntil(ctx, org, func() (done bool, err error) {
+		return len(org.Status.Members) == 2, nil
+	}))
+
+	viewerTemplate := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()) + "-viewer",
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToEveryone},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"apiserver.bulward.io"},
+					Resources: []string{"projects"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, viewerTemplate))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, viewerTemplate))
+
+	viewerRoleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      viewerTemplate.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, viewerRoleBinding, func() (done bool, err error) {
+		return len(viewerRoleBinding.Subjects) == 2, nil
+	}))
+	assert.Contains(t, viewerRoleBinding.Subjects, organizationOwner)
+	assert.Contains(t, viewerRoleBinding.Subjects, member)
+
+	t.Log("removed members are removed from the managed RoleBinding as well")
+	require.NoError(t, testutil.DeleteAndWaitUntilNotFound(ctx, cl, rb))
+	require.NoError(t, cl.WaitUntil(ctx, viewerRoleBinding, func() (done bool, err error) {
+		return len(viewerRoleBinding.Subjects) == 1, nil
+	}), "managed RoleBinding must not keep removed members")
+	require.NoError(t, cl.WaitUntil(ctx, org, func() (done bool, err error) {
+		return len(org.Status.Members) == 1, nil
+	}))
+}
+
+func TestStorageOrganizationRoleTemplateSelection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	owners := []rbacv1.Subject{{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Organization Owner",
+	}}
+	newOrganization := func(suffix string, labels map[string]string) *storagev1alpha1.Organization {
+		return &storagev1alpha1.Organization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   strings.ToLower(t.Name()) + "-" + suffix,
+				Labels: labels,
+			},
+			Spec: storagev1alpha1.OrganizationSpec{
+				Metadata: &storagev1alpha1.OrganizationMetadata{
+					DisplayName: suffix,
+					Description: "an organization to test template selection",
+				},
+				Owners: owners,
+			},
+		}
+	}
+	selected := newOrganization("selected", map[string]string{"tier": "premium"})
+	unselected := newOrganization("unselected", nil)
+	optedOut := newOrganization("opted-out", map[string]string{"tier": "premium"})
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			OrganizationSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"tier": "premium"},
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"apiserver.bulward.io"},
+					Resources: []string{"projects"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+	}
+	optedOut.Spec.ExcludedOrganizationRoleTemplates = []storagev1alpha1.ObjectReference{{Name: template.Name}}
+	for _, org := range []*storagev1alpha1.Organization{selected, unselected, optedOut} {
+		require.NoError(t, cl.Create(ctx, org))
+		require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.DefaultRoleName(),
+			Namespace: selected.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, role))
+	for _, org := range []*storagev1alpha1.Organization{unselected, optedOut} {
+		err := cl.Get(ctx, types.NamespacedName{
+			Name:      template.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		}, &rbacv1.Role{})
+		assert.True(t, errors.IsNotFound(err), "Role must not be created for Organization %s", org.Name)
+	}
+	require.NoError(t, cl.WaitUntil(ctx, template, func() (done bool, err error) {
+		return template.Status.Rollout.Targets == 1, nil
+	}))
+	assert.Equal(t, 1, template.Status.Rollout.Ready)
+
+	t.Log("opting out removes the Role again")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: selected.Name}, selected); err != nil {
+			return err
+		}
+		selected.Spec.ExcludedOrganizationRoleTemplates = []storagev1alpha1.ObjectReference{{Name: template.Name}}
+		return cl.Update(ctx, selected)
+	}))
+	require.NoError(t, testutil.WaitUntilNotFound(ctx, cl, role))
+}
+
+func TestStorageOrganizationRoleTemplateClusterRoleRef(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "leipzig",
+				Description: "an organization using integration ClusterRoles",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{rbacv1.VerbAll},
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, clusterRole))
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			ClusterRoleRef: &corev1alpha1.ObjectReference{
+				Name: clusterRole.Name,
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 1, nil
+	}))
+	assert.Equal(t, clusterRole.Rules[:1], role.Rules, "rules granting all verbs must be dropped")
+
+	t.Log("changes of the ClusterRole are rolled out again")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: clusterRole.Name}, clusterRole); err != nil {
+			return err
+		}
+		clusterRole.Rules[0].Verbs = []string{"get", "list"}
+		return cl.Update(ctx, clusterRole)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 1 && len(role.Rules[0].Verbs) == 2, nil
+	}), "copied rules must follow the ClusterRole")
+}
+
+func TestStorageOrganizationRoleTemplateIncludes(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "rostock",
+				Description: "an organization using composed role templates",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	readBulward := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()) + "-read-bulward",
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups:     []string{"apiserver.bulward.io"},
+					Resources:     []string{"organizations"},
+					ResourceNames: []string{"{{ .Organization.Name }}"},
+					Verbs:         []string{"get"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, readBulward))
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			Includes: []corev1alpha1.RoleTemplateReference{
+				{Kind: "OrganizationRoleTemplate", Name: readBulward.Name},
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"configmaps"},
+					Verbs:     []string{"get"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 2, nil
+	}))
+	assert.Equal(t, []string{org.Name}, role.Rules[1].ResourceNames, "included placeholders must be rendered for the target")
+
+	t.Log("changes of the included template are rolled out again")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: readBulward.Name}, readBulward); err != nil {
+			return err
+		}
+		readBulward.Spec.Rules[0].Verbs = []string{"get", "list"}
+		return cl.Update(ctx, readBulward)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 2 && len(role.Rules[1].Verbs) == 2, nil
+	}), "included rules must follow the included template")
+
+	t.Log("cycles are reported")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: readBulward.Name}, readBulward); err != nil {
+			return err
+		}
+		readBulward.Spec.Includes = []corev1alpha1.RoleTemplateReference{{Kind: "OrganizationRoleTemplate", Name: template.Name}}
+		return cl.Update(ctx, readBulward)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, template, func() (done bool, err error) {
+		condition, _ := template.Status.GetCondition(corev1alpha1.OrganizationRoleTemplateIncludeCycle)
+		return condition.Status == corev1alpha1.ConditionTrue, nil
+	}), "include cycle must be reported")
+	condition, _ := template.Status.GetCondition(corev1alpha1.OrganizationRoleTemplateIncludeCycle)
+	assert.Contains(t, condition.Message, readBulward.Name)
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, role))
+	assert.Len(t, role.Rules, 2, "the Role must be kept while the cycle exists")
+}
+
+func TestStorageOrganizationRoleTemplateCRDRules(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "dresden",
+				Description: "an organization using integration CRDs",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			CRDRules: []corev1alpha1.RoleTemplateCRDRules{{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"test.bulward.io/crd-rules": t.Name()},
+				},
+				Access: corev1alpha1.CRDAccessView,
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	t.Log("installed CRDs are added to the Role")
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "widgets.crdrules.test.bulward.io",
+			Labels: map[string]string{"test.bulward.io/crd-rules": t.Name()},
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "crdrules.test.bulward.io",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   "widgets",
+				Singular: "widget",
+				Kind:     "Widget",
+				ListKind: "WidgetList",
+			},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+				Name:    "v1alpha1",
+				Served:  true,
+				Storage: true,
+				Schema: &apiextensionsv1.CustomResourceValidation{
+					OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"},
+				},
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, crd))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 1, nil
+	}))
+	assert.Equal(t, []rbacv1.PolicyRule{{
+		APIGroups: []string{"crdrules.test.bulward.io"},
+		Resources: []string{"widgets"},
+		Verbs:     corev1alpha1.CRDAccessView.Verbs(),
+	}}, role.Rules)
+
+	t.Log("removed CRDs are removed from the Role")
+	require.NoError(t, cl.Delete(ctx, crd))
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 0, nil
+	}), "generated rules must follow the installed CRDs")
+}
+
+func TestStorageOrganizationRoleTemplateDrift(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   strings.ToLower(t.Name()),
+			Labels: map[string]string{"test.bulward.io/drift": t.Name()},
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "rostock",
+				Description: "an organization with tampered Roles",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			OrganizationSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"test.bulward.io/drift": t.Name()},
+			},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.DefaultRoleName(),
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return role.Annotations[corev1alpha1.LastAppliedRBACAnnotation] != "", nil
+	}), "the applied rules must be recorded")
+
+	t.Log("rules changed by others are corrected and reported")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, role); err != nil {
+			return err
+		}
+		role.Rules = append(role.Rules, rbacv1.PolicyRule{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"get"},
+		})
+		return cl.Update(ctx, role)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, role, func() (done bool, err error) {
+		return len(role.Rules) == 1, nil
+	}), "the added rule must be removed again")
+
+	events := &corev1.EventList{}
+	require.NoError(t, cl.List(ctx, events, client.InNamespace(role.Namespace)))
+	var found bool
+	for _, event := range events.Items {
+		if event.InvolvedObject.Kind == "Role" && event.InvolvedObject.Name == role.Name && event.Reason == "DriftCorrected" {
+			found = true
+			assert.Equal(t, "Corrected out-of-band changes to Role: added rules get secrets", event.Message)
+		}
+	}
+	assert.True(t, found, "the correction must be reported via an Event on the Role")
+}
+
+func TestStorageOrganizationRoleTemplatePlaceholders(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	// Organizations created via the apiserver have a generated name and carry the user facing name as label.
+	alias := "placeholders"
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   strings.ToLower(t.Name()),
+			Labels: map[string]string{storagev1alpha1.OrganizationNameLabel: alias},
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "hamburg",
+				Description: "an organization using placeholders",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	t.Log("invalid placeholders are rejected")
+	invalidTemplate := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()) + "-invalid",
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes:   []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			RoleName: "{{ .Cluster.Name }}",
+		},
+	}
+	err = cl.Create(ctx, invalidTemplate)
+	require.Error(t, err)
+	assert.True(t, errors.IsInvalid(err), "unknown placeholders must be rejected: %v", err)
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes:   []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo:   []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			RoleName: "{{ .Organization.Name }}-viewer",
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups:     []string{"apiserver.bulward.io"},
+				Resources:     []string{"organizations"},
+				ResourceNames: []string{"{{ .Organization.Name }}"},
+				Verbs:         []string{"get"},
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alias + "-viewer",
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, role))
+	assert.Equal(t, []string{alias}, role.Rules[0].ResourceNames)
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alias + "-viewer",
+			Namespace: org.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, roleBinding))
+	assert.Equal(t, role.Name, roleBinding.RoleRef.Name)
+}
+
+func TestStorageOrganizationRoleTemplateStagedRollout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	testLabels := map[string]string{"test.bulward.io/staged-rollout": strings.ToLower(t.Name())}
+	newOrganization := func(suffix string, canary bool) *storagev1alpha1.Organization {
+		org := &storagev1alpha1.Organization{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   strings.ToLower(t.Name()) + "-" + suffix,
+				Labels: map[string]string{},
+			},
+			Spec: storagev1alpha1.OrganizationSpec{
+				Metadata: &storagev1alpha1.OrganizationMetadata{
+					DisplayName: suffix,
+					Description: "an organization to test staged rollouts",
+				},
+				Owners: []rbacv1.Subject{{
+					Kind:     rbacv1.UserKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     "Organization Owner",
+				}},
+			},
+		}
+		for k, v := range testLabels {
+			org.Labels[k] = v
+		}
+		if canary {
+			org.Labels["bulward.io/canary"] = "true"
+		}
+		return org
+	}
+	canary := newOrganization("canary", true)
+	other := newOrganization("other", false)
+	for _, org := range []*storagev1alpha1.Organization{canary, other} {
+		require.NoError(t, cl.Create(ctx, org))
+		require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+	}
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes:               []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo:               []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			OrganizationSelector: &metav1.LabelSelector{MatchLabels: testLabels},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"apiserver.bulward.io"},
+				Resources: []string{"projects"},
+				Verbs:     []string{"get"},
+			}},
+			RolloutStrategy: &corev1alpha1.OrganizationRoleTemplateRolloutStrategy{
+				Canary: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"bulward.io/canary": "true"},
+				},
+				BatchSize: 1,
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	roleIn := func(org *storagev1alpha1.Organization) *rbacv1.Role {
+		return &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      template.DefaultRoleName(),
+				Namespace: org.Status.Namespace.Name,
+			},
+		}
+	}
+	for _, org := range []*storagev1alpha1.Organization{canary, other} {
+		require.NoError(t, testutil.WaitUntilFound(ctx, cl, roleIn(org)), "the first rollout must reach all Organizations batch by batch")
+	}
+
+	t.Log("paused rollouts keep the previous revision")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: template.Name}, template); err != nil {
+			return err
+		}
+		template.Spec.Rules[0].Verbs = []string{"get", "list"}
+		template.Spec.RolloutStrategy.Paused = true
+		return cl.Update(ctx, template)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, template, func() (done bool, err error) {
+		return template.Status.StagedRollout != nil && template.Status.StagedRollout.Phase == corev1alpha1.StagedRolloutPaused, nil
+	}))
+	assert.Equal(t, 0, template.Status.StagedRollout.UpdatedOrganizations)
+	assert.Equal(t, 2, template.Status.Rollout.Pending)
+	for _, org := range []*storagev1alpha1.Organization{canary, other} {
+		role := roleIn(org)
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, role))
+		assert.Len(t, role.Rules[0].Verbs, 1, "Role of %s must not be updated while paused", org.Name)
+	}
+
+	t.Log("RoleBindings follow the owners while paused")
+	newOwner := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "New Owner",
+	}
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: other.Name}, other); err != nil {
+			return err
+		}
+		other.Spec.Owners = []rbacv1.Subject{newOwner}
+		return cl.Update(ctx, other)
+	}))
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.DefaultRoleName(),
+			Namespace: other.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, roleBinding, func() (done bool, err error) {
+		return len(roleBinding.Subjects) == 1 && roleBinding.Subjects[0].Name == newOwner.Name, nil
+	}), "the removed owner must lose access, while the rollout is paused")
+	role := roleIn(other)
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, role))
+	assert.Len(t, role.Rules[0].Verbs, 1, "Role of %s must still not be updated", other.Name)
+
+	t.Log("resumed rollouts update all Organizations batch by batch")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: template.Name}, template); err != nil {
+			return err
+		}
+		template.Spec.RolloutStrategy.Paused = false
+		return cl.Update(ctx, template)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, template, func() (done bool, err error) {
+		return template.Status.StagedRollout != nil && template.Status.StagedRollout.Phase == corev1alpha1.StagedRolloutComplete, nil
+	}))
+	assert.Equal(t, 2, template.Status.StagedRollout.UpdatedOrganizations)
+	for _, org := range []*storagev1alpha1.Organization{canary, other} {
+		role := roleIn(org)
+		require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: role.Name, Namespace: role.Namespace}, role))
+		assert.Len(t, role.Rules[0].Verbs, 2, "Role of %s must be updated", org.Name)
+		assert.Equal(t, template.Status.StagedRollout.Revision, role.Annotations[corev1alpha1.RoleTemplateRevisionAnnotation])
+	}
+}
+
+func TestStorageOrganizationRoleRevocation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   strings.ToLower(t.Name()),
+			Labels: map[string]string{"test.bulward.io/role-revocation": t.Name()},
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "dresden",
+				Description: "an organization with user created Roles",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	template := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: corev1alpha1.OrganizationRoleTemplateSpec{
+			Scopes: []corev1alpha1.RoleTemplateScope{corev1alpha1.RoleTemplateScopeOrganization},
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			OrganizationSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"test.bulward.io/role-revocation": t.Name()},
+			},
+			Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get"},
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, template))
+
+	// No role template grants access to the test.bulward.io API group.
+	widgetsRule := rbacv1.PolicyRule{
+		APIGroups: []string{"test.bulward.io"},
+		Resources: []string{"widgets"},
+		Verbs:     []string{"get"},
+	}
+	setRevocationMode := func(mode corev1alpha1.RoleRevocationMode) {
+		ns := &corev1.Namespace{}
+		require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := cl.Get(ctx, types.NamespacedName{Name: org.Status.Namespace.Name}, ns); err != nil {
+				return err
+			}
+			ns.Labels[corev1alpha1.RoleRevocationModeLabel] = string(mode)
+			return cl.Update(ctx, ns)
+		}))
+	}
+
+	t.Log("rules exceeding the role templates are revoked")
+	setRevocationMode(corev1alpha1.RoleRevocationEnforce)
+	userRole := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "user-role",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Rules: append([]rbacv1.PolicyRule{}, template.Spec.Rules[0], widgetsRule),
+	}
+	require.NoError(t, cl.Create(ctx, userRole))
+	require.NoError(t, cl.WaitUntil(ctx, userRole, func() (done bool, err error) {
+		return len(userRole.Rules) == 1, nil
+	}), "the widgets rule must be revoked")
+	assert.Equal(t, template.Spec.Rules, userRole.Rules)
+	assert.JSONEq(t, `{"rules":[{"apiGroups":["test.bulward.io"],"resources":["widgets"],"verbs":["get"]}]}`,
+		userRole.Annotations[corev1alpha1.RoleRevocationAnnotation])
+	event, err := waitForRoleEvent(ctx, cl, userRole, "RulesRevoked")
+	require.NoError(t, err, "the revocation must be reported via an Event on the Role")
+	assert.Equal(t, "Revoked rules that are not granted by any role template: get widgets.test.bulward.io", event.Message)
+
+	t.Log("Roles of role templates are left alone")
+	templateRole := &rbacv1.Role{}
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: template.DefaultRoleName(), Namespace: org.Status.Namespace.Name}, templateRole))
+	assert.Equal(t, template.Spec.Rules, templateRole.Rules)
+	assert.NotContains(t, templateRole.Annotations, corev1alpha1.RoleRevocationAnnotation)
+
+	t.Log("dry-run only reports exceeding rules")
+	setRevocationMode(corev1alpha1.RoleRevocationDryRun)
+	dryRunRole := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dry-run-role",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Rules: []rbacv1.PolicyRule{widgetsRule},
+	}
+	require.NoError(t, cl.Create(ctx, dryRunRole))
+	require.NoError(t, cl.WaitUntil(ctx, dryRunRole, func() (done bool, err error) {
+		_, ok := dryRunRole.Annotations[corev1alpha1.RoleRevocationAnnotation]
+		return ok, nil
+	}), "the widgets rule must be reported")
+	assert.Equal(t, []rbacv1.PolicyRule{widgetsRule}, dryRunRole.Rules)
+	assert.JSONEq(t, `{"dryRun":true,"rules":[{"apiGroups":["test.bulward.io"],"resources":["widgets"],"verbs":["get"]}]}`,
+		dryRunRole.Annotations[corev1alpha1.RoleRevocationAnnotation])
+	event, err = waitForRoleEvent(ctx, cl, dryRunRole, "RulesExceedTemplates")
+	require.NoError(t, err, "the exceeding rules must be reported via an Event on the Role")
+	assert.Equal(t, "Rules are not granted by any role template and would be revoked: get widgets.test.bulward.io", event.Message)
+
+	t.Log("dry-run reports are cleared, once the role templates cover the Role")
+	require.NoError(t, retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := cl.Get(ctx, types.NamespacedName{Name: template.Name}, template); err != nil {
+			return err
+		}
+		template.Spec.Rules = append(template.Spec.Rules, widgetsRule)
+		return cl.Update(ctx, template)
+	}))
+	require.NoError(t, cl.WaitUntil(ctx, dryRunRole, func() (done bool, err error) {
+		_, ok := dryRunRole.Annotations[corev1alpha1.RoleRevocationAnnotation]
+		return !ok, nil
+	}), "the stale report must be removed")
+	assert.Equal(t, []rbacv1.PolicyRule{widgetsRule}, dryRunRole.Rules)
+}
+
+// waitForRoleEvent waits until an Event with the reason was recorded for the Role.
+func waitForRoleEvent(ctx context.Context, cl client.Client, role *rbacv1.Role, reason string) (*corev1.Event, error) {
+	var found *corev1.Event
+	err := wait.PollImmediate(time.Second, time.Minute, func() (done bool, err error) {
+		events := &corev1.EventList{}
+		if err := cl.List(ctx, events, client.InNamespace(role.Namespace)); err != nil {
+			return false, err
+		}
+		for i, event := range events.Items {
+			if event.InvolvedObject.Kind == "Role" && event.InvolvedObject.Name == role.Name && event.Reason == reason {
+				found = &events.Items[i]
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return found, err
+}
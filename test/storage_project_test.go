@@ -18,16 +18,18 @@ package test
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8c.io/utils/pkg/owner"
 	"k8c.io/utils/pkg/testutil"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
@@ -35,6 +37,7 @@ import (
 
 	corev1alpha1 "k8c.io/bulward/pkg/apis/core/v1alpha1"
 	storagev1alpha1 "k8c.io/bulward/pkg/apis/storage/v1alpha1"
+	"k8c.io/bulward/pkg/naming"
 	"k8c.io/bulward/pkg/templates"
 )
 
@@ -105,20 +108,25 @@ func TestStorageProject(t *testing.T) {
 	require.NoError(t, cl.Create(ctx, project))
 	require.NoError(t, testutil.WaitUntilReady(ctx, cl, project))
 
+	expectedNamespace, err := naming.DefaultNamespacePolicy().ProjectNamespace(project)
+	require.NoError(t, err)
+	if assert.NotNil(t, project.Status.Namespace) {
+		assert.Equal(t, expectedNamespace, project.Status.Namespace.Name)
+	}
 	projectNs := &corev1.Namespace{}
-	projectNs.Name = fmt.Sprintf("%s-%s", project.Namespace, project.Name)
+	projectNs.Name = expectedNamespace
 	require.NoError(t, testutil.WaitUntilFound(ctx, cl, projectNs))
 
 	// Make sure Role/RoleBinding for Organization Owner has been created in Project namespace.
 	role := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      rbacTemplate.Name,
+			Name:      rbacTemplate.DefaultRoleName(),
 			Namespace: projectNs.Name,
 		},
 	}
 	roleBinding := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      rbacTemplate.Name,
+			Name:      rbacTemplate.DefaultRoleName(),
 			Namespace: projectNs.Name,
 		},
 	}
@@ -143,20 +151,91 @@ func TestStorageProject(t *testing.T) {
 		RoleRef: rbacv1.RoleRef{
 			APIGroup: rbacv1.GroupName,
 			Kind:     "Role",
-			Name:     rbacTemplate.Name,
+			Name:     rbacTemplate.DefaultRoleName(),
 		},
 	}
 	require.NoError(t, ownerClient.Create(ctx, rb))
 	require.NoError(t, cl.WaitUntil(ctx, project, func() (done bool, err error) {
 		if len(project.Status.Members) == 2 {
 			assert.Contains(t, project.Status.Members, rbacSubject)
-			// A RoleBinding for Organization Owner will also be created to grant Owner to have permission to create Role/RoleBindings in Project namespace.
-			assert.Contains(t, project.Status.Members, organizationOwner)
+			assert.Contains(t, project.Status.Members, projectOwner)
+			// RoleBindings managed by OrganizationRoleTemplates don't make the Organization Owner a member of the Project.
+			assert.NotContains(t, project.Status.Members, organizationOwner)
 			return true, nil
 		}
 		return false, nil
 	}), "project didnt reconcile added member")
 
+	t.Log("Organization Owner can not grant permissions via ProjectRoleTemplate, that the owner does not hold.")
+	escalatingTemplate := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "escalating-template",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"secrets"},
+					Verbs:     []string{"get", "list"},
+				},
+			},
+		},
+	}
+	err = ownerClient.Create(ctx, escalatingTemplate)
+	assert.True(t, errors.IsInvalid(err), "ProjectRoleTemplate with escalating rules should be rejected, got %v", err)
+
+	t.Log("Organization Owner can not grant permissions via ProjectRoleTemplate, that the owner only holds in the Organization namespace.")
+	secretReader := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "secret-reader",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+			Verbs:     []string{"get"},
+		}},
+	}
+	require.NoError(t, cl.Create(ctx, secretReader))
+	secretReaderBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "secret-reader",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Subjects: []rbacv1.Subject{organizationOwner},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     secretReader.Name,
+		},
+	}
+	require.NoError(t, cl.Create(ctx, secretReaderBinding))
+	secretTemplate := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "secret-template",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			BindTo:          []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			Rules:           secretReader.Rules,
+			ProjectSelector: &metav1.LabelSelector{},
+		},
+	}
+	err = ownerClient.Create(ctx, secretTemplate)
+	if assert.True(t, errors.IsInvalid(err), "ProjectRoleTemplate with rules escalating in Project namespaces should be rejected, got %v", err) {
+		assert.Contains(t, err.Error(), "in namespace "+projectNs.Name)
+	}
+
+	t.Log("Organization Owner can change a ProjectRoleTemplate without touching permissions granted by someone else.")
+	require.NoError(t, cl.Create(ctx, secretTemplate))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, secretTemplate))
+	require.NoError(t, ownerClient.Get(ctx, types.NamespacedName{Name: secretTemplate.Name, Namespace: secretTemplate.Namespace}, secretTemplate))
+	secretTemplate.Spec.BindTo = []corev1alpha1.BindingType{corev1alpha1.BindToEveryone}
+	require.NoError(t, ownerClient.Update(ctx, secretTemplate))
+	require.NoError(t, cl.Delete(ctx, secretTemplate))
+
 	t.Log("Organization Owner has permission to create ProjectRoleTemplate.")
 	projectRoleTemplate := &corev1alpha1.ProjectRoleTemplate{
 		ObjectMeta: metav1.ObjectMeta{
@@ -167,9 +246,9 @@ func TestStorageProject(t *testing.T) {
 			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToEveryone},
 			Rules: []rbacv1.PolicyRule{
 				{
-					APIGroups: []string{"myapp.io"},
-					Resources: []string{"myapp"},
-					Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+					APIGroups: []string{rbacv1.GroupName},
+					Resources: []string{"roles", "rolebindings"},
+					Verbs:     []string{"get", "list", "watch"},
 				},
 			},
 			ProjectSelector: &metav1.LabelSelector{},
@@ -180,20 +259,19 @@ func TestStorageProject(t *testing.T) {
 	// Make sure Role/RoleBinding for managed by ProjectRoleTemplate has been created in Project namespace.
 	projectRole := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      projectRoleTemplate.Name,
+			Name:      projectRoleTemplate.DefaultRoleName(),
 			Namespace: projectNs.Name,
 		},
 	}
 	projectRoleBinding := &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      projectRoleTemplate.Name,
+			Name:      projectRoleTemplate.DefaultRoleName(),
 			Namespace: projectNs.Name,
 		},
 	}
 	require.NoError(t, testutil.WaitUntilFound(ctx, cl, projectRole))
 	require.NoError(t, testutil.WaitUntilFound(ctx, cl, projectRoleBinding))
 	assert.Contains(t, projectRoleBinding.Subjects, rbacSubject)
-	assert.Contains(t, projectRoleBinding.Subjects, organizationOwner)
 	assert.Contains(t, projectRoleBinding.Subjects, projectOwner)
 
 	require.NoError(t, ownerClient.Delete(ctx, projectRoleTemplate))
@@ -208,3 +286,581 @@ func TestStorageProject(t *testing.T) {
 	require.NoError(t, cl.Delete(ctx, project))
 	require.NoError(t, cl.WaitUntilNotFound(ctx, projectNs), "Project namespace has not been cleaned up")
 }
+
+func TestStorageProjectRoleTemplatePruning(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "cologne",
+				Description: "an organization with shrinking templates",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	project := &storagev1alpha1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pruning-project",
+			Namespace: org.Status.Namespace.Name,
+			Labels: map[string]string{
+				"team": "a",
+			},
+		},
+		Spec: storagev1alpha1.ProjectSpec{
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Project Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, project))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, project))
+
+	projectRoleTemplate := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team-a-template",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{rbacv1.GroupName},
+					Resources: []string{"roles"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+			ProjectSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"team": "a",
+				},
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, projectRoleTemplate))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, projectRoleTemplate))
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      projectRoleTemplate.DefaultRoleName(),
+			Namespace: project.Status.Namespace.Name,
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      projectRoleTemplate.DefaultRoleName(),
+			Namespace: project.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, role))
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, roleBinding))
+
+	t.Log("Role/RoleBinding are pruned when the Project is no longer selected")
+	require.NoError(t, testutil.TryUpdateUntil(ctx, cl, project, func() error {
+		project.Labels["team"] = "b"
+		return nil
+	}))
+	require.NoError(t, testutil.WaitUntilNotFound(ctx, cl, role))
+	require.NoError(t, testutil.WaitUntilNotFound(ctx, cl, roleBinding))
+	require.NoError(t, cl.WaitUntil(ctx, projectRoleTemplate, func() (done bool, err error) {
+		return projectRoleTemplate.Status.Rollout.Targets == 0, nil
+	}), "unselected Project was not removed from targets")
+}
+
+func TestStorageProjectRoleTemplateRolloutFailure(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "bremen",
+				Description: "an organization with a broken project namespace",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	var projects []*storagev1alpha1.Project
+	for _, name := range []string{"broken", "healthy"} {
+		project := &storagev1alpha1.Project{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: org.Status.Namespace.Name,
+			},
+			Spec: storagev1alpha1.ProjectSpec{
+				Owners: []rbacv1.Subject{{
+					Kind:     rbacv1.UserKind,
+					APIGroup: rbacv1.GroupName,
+					Name:     "Project Owner",
+				}},
+			},
+		}
+		require.NoError(t, cl.Create(ctx, project))
+		require.NoError(t, testutil.WaitUntilReady(ctx, cl, project))
+		projects = append(projects, project)
+	}
+	broken, healthy := projects[0], projects[1]
+
+	projectRoleTemplate := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rollout-template",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{rbacv1.GroupName},
+					Resources: []string{"roles"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+	}
+
+	// A Role owned by someone else blocks the rollout to the broken Project.
+	conflictingRole := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      projectRoleTemplate.DefaultRoleName(),
+			Namespace: broken.Status.Namespace.Name,
+			Labels: map[string]string{
+				owner.OwnerTypeLabel: "Other.example.com",
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, conflictingRole))
+	require.NoError(t, cl.Create(ctx, projectRoleTemplate))
+
+	require.NoError(t, cl.WaitUntil(ctx, projectRoleTemplate, func() (done bool, err error) {
+		condition, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateDegraded)
+		return condition.Status == corev1alpha1.ConditionTrue, nil
+	}), "ProjectRoleTemplate must be degraded")
+	assert.Equal(t, 2, projectRoleTemplate.Status.Rollout.Targets)
+	assert.Equal(t, 1, projectRoleTemplate.Status.Rollout.Ready)
+	assert.Equal(t, 1, projectRoleTemplate.Status.Rollout.Failed)
+	if assert.Len(t, projectRoleTemplate.Status.Rollout.FailedTargets, 1) {
+		failed := projectRoleTemplate.Status.Rollout.FailedTargets[0]
+		assert.Equal(t, broken.Name, failed.Name)
+		assert.Equal(t, corev1alpha1.RoleTemplateTargetFailed, failed.State)
+		assert.NotEmpty(t, failed.Message)
+		assert.NotNil(t, failed.LastAttemptTime)
+	}
+	conflict, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateConflict)
+	assert.Equal(t, corev1alpha1.ConditionTrue, conflict.Status)
+	assert.Equal(t, corev1alpha1.ProjectRoleTemplateNameConflictReason, conflict.Reason)
+	assert.Contains(t, conflict.Message, "Other.example.com")
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: conflictingRole.Name, Namespace: conflictingRole.Namespace}, conflictingRole))
+	assert.Equal(t, "Other.example.com", conflictingRole.Labels[owner.OwnerTypeLabel], "the conflicting Role must not be taken over")
+
+	t.Log("the healthy Project is not blocked by the broken one")
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      projectRoleTemplate.DefaultRoleName(),
+			Namespace: healthy.Status.Namespace.Name,
+		},
+	}))
+
+	t.Log("the rollout recovers, when the conflict is resolved")
+	require.NoError(t, testutil.DeleteAndWaitUntilNotFound(ctx, cl, conflictingRole))
+	require.NoError(t, cl.WaitUntil(ctx, projectRoleTemplate, func() (done bool, err error) {
+		condition, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateDegraded)
+		return condition.Status == corev1alpha1.ConditionFalse, nil
+	}), "ProjectRoleTemplate must recover")
+	assert.Equal(t, 2, projectRoleTemplate.Status.Rollout.Ready)
+	assert.Empty(t, projectRoleTemplate.Status.Rollout.FailedTargets)
+	conflict, _ = projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateConflict)
+	assert.Equal(t, corev1alpha1.ConditionFalse, conflict.Status)
+}
+
+func TestStorageProjectRoleTemplateNameConflict(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "kiel",
+				Description: "an organization with competing role templates",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	project := &storagev1alpha1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "project",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: storagev1alpha1.ProjectSpec{
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Project Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, project))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, project))
+
+	rbacTemplate := &corev1alpha1.OrganizationRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: templates.RBACAdminOrganizationRoleTemplateName,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, rbacTemplate))
+	rbacRole := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      rbacTemplate.DefaultRoleName(),
+			Namespace: project.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, rbacRole))
+
+	t.Log("a ProjectRoleTemplate named like an OrganizationRoleTemplate doesn't collide with it")
+	projectRoleTemplate := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      templates.RBACAdminOrganizationRoleTemplateName,
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToOwners},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{rbacv1.GroupName},
+					Resources: []string{"roles"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, projectRoleTemplate))
+	require.NoError(t, cl.WaitUntil(ctx, projectRoleTemplate, func() (done bool, err error) {
+		return projectRoleTemplate.Status.Rollout.Ready == 1, nil
+	}), "ProjectRoleTemplate must be rolled out")
+	conflict, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateConflict)
+	assert.Equal(t, corev1alpha1.ConditionFalse, conflict.Status)
+	require.NoError(t, testutil.WaitUntilFound(ctx, cl, &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      projectRoleTemplate.DefaultRoleName(),
+			Namespace: project.Status.Namespace.Name,
+		},
+	}))
+
+	t.Log("a ProjectRoleTemplate reusing the Role name of an OrganizationRoleTemplate reports the conflict")
+	projectRoleTemplate.Spec.RoleName = rbacTemplate.DefaultRoleName()
+	require.NoError(t, cl.Update(ctx, projectRoleTemplate))
+	require.NoError(t, cl.WaitUntil(ctx, projectRoleTemplate, func() (done bool, err error) {
+		condition, _ := projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateConflict)
+		return condition.Status == corev1alpha1.ConditionTrue, nil
+	}), "ProjectRoleTemplate must report the conflict")
+	conflict, _ = projectRoleTemplate.Status.GetCondition(corev1alpha1.ProjectRoleTemplateConflict)
+	assert.Equal(t, corev1alpha1.ProjectRoleTemplateNameConflictReason, conflict.Reason)
+	assert.Contains(t, conflict.Message, "OrganizationRoleTemplate.bulward.io "+rbacTemplate.Name)
+
+	require.NoError(t, cl.Get(ctx, types.NamespacedName{Name: rbacRole.Name, Namespace: rbacRole.Namespace}, rbacRole))
+	assert.Equal(t, rbacTemplate.Name, rbacRole.Labels[owner.OwnerNameLabel], "the Role of the OrganizationRoleTemplate must not be taken over")
+	assert.NotEqual(t, projectRoleTemplate.Spec.Rules, rbacRole.Rules)
+}
+
+func TestStorageProjectRoleTemplateValidation(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "flensburg",
+				Description: "an organization with invalid role templates",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	validRules := []rbacv1.PolicyRule{{
+		APIGroups: []string{""},
+		Resources: []string{"configmaps"},
+		Verbs:     []string{"get"},
+	}}
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		spec      corev1alpha1.ProjectRoleTemplateSpec
+		field     string
+	}{
+		{
+			name:      "empty-rules",
+			namespace: org.Status.Namespace.Name,
+			spec:      corev1alpha1.ProjectRoleTemplateSpec{ProjectSelector: &metav1.LabelSelector{}},
+			field:     "spec.rules",
+		},
+		{
+			name:      "invalid-selector",
+			namespace: org.Status.Namespace.Name,
+			spec: corev1alpha1.ProjectRoleTemplateSpec{
+				ProjectSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Contains"}},
+				},
+				Rules: validRules,
+			},
+			field: "spec.projectSelector.matchExpressions[0].operator",
+		},
+		{
+			name:      "non-organization-namespace",
+			namespace: "default",
+			spec:      corev1alpha1.ProjectRoleTemplateSpec{ProjectSelector: &metav1.LabelSelector{}, Rules: validRules},
+			field:     "metadata.namespace",
+		},
+		{
+			name:      "escalate",
+			namespace: org.Status.Namespace.Name,
+			spec: corev1alpha1.ProjectRoleTemplateSpec{
+				ProjectSelector: &metav1.LabelSelector{},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{rbacv1.GroupName},
+					Resources: []string{"roles"},
+					Verbs:     []string{"get", "escalate"},
+				}},
+			},
+			field: "spec.rules[0].verbs[1]",
+		},
+		{
+			name:      "impersonate",
+			namespace: org.Status.Namespace.Name,
+			spec: corev1alpha1.ProjectRoleTemplateSpec{
+				ProjectSelector: &metav1.LabelSelector{},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"serviceaccounts"},
+					Verbs:     []string{"impersonate"},
+				}},
+			},
+			field: "spec.rules[0].verbs[0]",
+		},
+		{
+			name:      "wildcard-verb",
+			namespace: org.Status.Namespace.Name,
+			spec: corev1alpha1.ProjectRoleTemplateSpec{
+				ProjectSelector: &metav1.LabelSelector{},
+				Rules: []rbacv1.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"configmaps"},
+					Verbs:     []string{rbacv1.VerbAll},
+				}},
+			},
+			field: "spec.rules[0].verbs[0]",
+		},
+	} {
+		template := &corev1alpha1.ProjectRoleTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tc.name,
+				Namespace: tc.namespace,
+			},
+			Spec: tc.spec,
+		}
+		err := cl.Create(ctx, template)
+		require.Error(t, err, "%s must be rejected", tc.name)
+		assert.True(t, errors.IsInvalid(err), "%s must be invalid, got %v", tc.name, err)
+		assert.Contains(t, err.Error(), tc.field, "%s must be reported for the field", tc.name)
+	}
+
+	t.Log("bindTo defaults to the owners")
+	template := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "defaulted",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			ProjectSelector: &metav1.LabelSelector{},
+			Rules:           validRules,
+		},
+	}
+	require.NoError(t, cl.Create(ctx, template))
+	assert.Equal(t, []corev1alpha1.BindingType{corev1alpha1.BindToOwners}, template.Spec.BindTo)
+}
+
+func TestStorageProjectMemberProvenance(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	cfg, err := controllerruntime.GetConfig()
+	require.NoError(t, err)
+	cl := testutil.NewRecordingClient(t, cfg, testScheme, testutil.CleanUpStrategy(cleanUpStrategy))
+	t.Cleanup(cl.CleanUpFunc(ctx))
+
+	org := &storagev1alpha1.Organization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(t.Name()),
+		},
+		Spec: storagev1alpha1.OrganizationSpec{
+			Metadata: &storagev1alpha1.OrganizationMetadata{
+				DisplayName: "hamburg",
+				Description: "an organization with leaving members",
+			},
+			Owners: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "Organization Owner",
+			}},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, org))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, org))
+
+	projectOwner := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Project Owner",
+	}
+	project := &storagev1alpha1.Project{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "provenance-project",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: storagev1alpha1.ProjectSpec{
+			Owners: []rbacv1.Subject{projectOwner},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, project))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, project))
+
+	// A ProjectRoleTemplate bound to everyone binds all members again, which must not keep them members.
+	projectRoleTemplate := &corev1alpha1.ProjectRoleTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "everyone-template",
+			Namespace: org.Status.Namespace.Name,
+		},
+		Spec: corev1alpha1.ProjectRoleTemplateSpec{
+			BindTo: []corev1alpha1.BindingType{corev1alpha1.BindToEveryone},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{rbacv1.GroupName},
+					Resources: []string{"roles"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+			},
+			ProjectSelector: &metav1.LabelSelector{},
+		},
+	}
+	require.NoError(t, cl.Create(ctx, projectRoleTemplate))
+	require.NoError(t, testutil.WaitUntilReady(ctx, cl, projectRoleTemplate))
+
+	member := rbacv1.Subject{
+		Kind:     rbacv1.UserKind,
+		APIGroup: rbacv1.GroupName,
+		Name:     "Member",
+	}
+	rb := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "member-rb",
+			Namespace: project.Status.Namespace.Name,
+		},
+		Subjects: []rbacv1.Subject{member},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     projectRoleTemplate.DefaultRoleName(),
+		},
+	}
+	require.NoError(t, cl.Create(ctx, rb))
+	require.NoError(t, cl.WaitUntil(ctx, project, func() (done bool, err error) {
+		for _, provenance := range project.Status.MemberProvenance {
+			if provenance.Subject == member {
+				assert.Equal(t, []storagev1alpha1.MemberSource{{
+					Kind: storagev1alpha1.MemberSourceRoleBinding,
+					Name: rb.Name,
+				}}, provenance.Sources)
+				return true, nil
+			}
+		}
+		return false, nil
+	}), "project didnt record the provenance of the added member")
+
+	templateRoleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      projectRoleTemplate.DefaultRoleName(),
+			Namespace: project.Status.Namespace.Name,
+		},
+	}
+	require.NoError(t, cl.WaitUntil(ctx, templateRoleBinding, func() (done bool, err error) {
+		for _, subject := range templateRoleBinding.Subjects {
+			if subject == member {
+				return true, nil
+			}
+		}
+		return false, nil
+	}), "member was not bound by the ProjectRoleTemplate")
+
+	t.Log("Removing the user created RoleBinding removes the member, although the template still binds it")
+	require.NoError(t, testutil.DeleteAndWaitUntilNotFound(ctx, cl, rb))
+	require.NoError(t, cl.WaitUntil(ctx, project, func() (done bool, err error) {
+		if len(project.Status.Members) != 1 {
+			return false, nil
+		}
+		assert.Equal(t, []rbacv1.Subject{projectOwner}, project.Status.Members)
+		return true, nil
+	}), "project didnt reconcile removed member")
+	require.NoError(t, cl.WaitUntil(ctx, templateRoleBinding, func() (done bool, err error) {
+		return len(templateRoleBinding.Subjects) == 1, nil
+	}), "removed member is still bound by the ProjectRoleTemplate")
+}